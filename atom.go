@@ -0,0 +1,530 @@
+package gopodcast
+
+import (
+	"crypto/sha1"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const atomNamespaceURL = "http://www.w3.org/2005/Atom"
+
+// atomFeed is an Atom 1.0 feed. The embedded xmlFixPodcast decodes extension
+// elements, such as those in the iTunes namespace, and unknown elements. The
+// Atom elements are mapped onto the podcast by translate, and take precedence
+// over any elements with the same name in the embedded struct because
+// encoding/xml uses the first matching field, so it must be embedded last.
+type atomFeed struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+
+	Lang       string         `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	ID         string         `xml:"http://www.w3.org/2005/Atom id"`
+	Title      atomText       `xml:"http://www.w3.org/2005/Atom title"`
+	Subtitle   atomText       `xml:"http://www.w3.org/2005/Atom subtitle"`
+	Rights     atomText       `xml:"http://www.w3.org/2005/Atom rights"`
+	Updated    *Time          `xml:"http://www.w3.org/2005/Atom updated"`
+	Generator  string         `xml:"http://www.w3.org/2005/Atom generator"`
+	Icon       string         `xml:"http://www.w3.org/2005/Atom icon"`
+	Logo       string         `xml:"http://www.w3.org/2005/Atom logo"`
+	Links      []atomLink     `xml:"http://www.w3.org/2005/Atom link"`
+	Authors    []atomPerson   `xml:"http://www.w3.org/2005/Atom author"`
+	Categories []atomCategory `xml:"http://www.w3.org/2005/Atom category"`
+	Entries    []*atomEntry   `xml:"http://www.w3.org/2005/Atom entry"`
+
+	xmlFixPodcast
+}
+
+// atomEntry is an entry in an Atom 1.0 feed, decoded in the same way as
+// atomFeed.
+type atomEntry struct {
+	ID         string         `xml:"http://www.w3.org/2005/Atom id"`
+	Title      atomText       `xml:"http://www.w3.org/2005/Atom title"`
+	Summary    atomText       `xml:"http://www.w3.org/2005/Atom summary"`
+	Content    atomText       `xml:"http://www.w3.org/2005/Atom content"`
+	Published  *Time          `xml:"http://www.w3.org/2005/Atom published"`
+	Updated    *Time          `xml:"http://www.w3.org/2005/Atom updated"`
+	Links      []atomLink     `xml:"http://www.w3.org/2005/Atom link"`
+	Authors    []atomPerson   `xml:"http://www.w3.org/2005/Atom author"`
+	Categories []atomCategory `xml:"http://www.w3.org/2005/Atom category"`
+
+	xmlFixItem
+}
+
+// atomText is an Atom text construct, where Type is "text" (the default),
+// "html" or "xhtml". For xhtml, Text is the markup inside the wrapping div.
+type atomText struct {
+	Type string
+	Text string
+}
+
+func (t *atomText) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		if a.Name.Local == "type" {
+			t.Type = a.Value
+		}
+	}
+
+	// innerxml isn't available when decoding from a token reader, so the
+	// markup of xhtml content is written back out token by token
+	var text, markup strings.Builder
+	e := xml.NewEncoder(&markup)
+	depth := 0
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tt := tok.(type) {
+		case xml.StartElement:
+			depth++
+			if depth > 1 {
+				el := xml.StartElement{Name: xml.Name{Local: strings.TrimPrefix(tt.Name.Local, unmatchedElementPrefix)}}
+				for _, a := range tt.Attr {
+					if !isNamespaceDecl(a) {
+						el.Attr = append(el.Attr, xml.Attr{Name: xml.Name{Local: a.Name.Local}, Value: a.Value})
+					}
+				}
+				if err := e.EncodeToken(el); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				if t.Type == "xhtml" {
+					if err := e.Flush(); err != nil {
+						return err
+					}
+					t.Text = strings.TrimSpace(markup.String())
+				} else {
+					t.Text = strings.TrimSpace(text.String())
+				}
+				return nil
+			}
+			if depth > 1 {
+				if err := e.EncodeToken(xml.EndElement{Name: xml.Name{Local: strings.TrimPrefix(tt.Name.Local, unmatchedElementPrefix)}}); err != nil {
+					return err
+				}
+			}
+			depth--
+		case xml.CharData:
+			if depth == 0 {
+				text.Write(tt)
+			} else if err := e.EncodeToken(tt); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+	Title  string `xml:"title,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name  string `xml:"http://www.w3.org/2005/Atom name"`
+	Email string `xml:"http://www.w3.org/2005/Atom email,omitempty"`
+	URI   string `xml:"http://www.w3.org/2005/Atom uri,omitempty"`
+}
+
+type atomCategory struct {
+	Term   string `xml:"term,attr"`
+	Scheme string `xml:"scheme,attr,omitempty"`
+	Label  string `xml:"label,attr,omitempty"`
+}
+
+// atomHeader and atomEntryHeader are the Atom elements of a feed and entry
+// when writing, which are followed by any extension elements.
+type atomHeader struct {
+	ID         string          `xml:"id"`
+	Title      atomTextOut     `xml:"title"`
+	Subtitle   *atomTextOut    `xml:"subtitle,omitempty"`
+	Updated    string          `xml:"updated"`
+	Links      []atomLink      `xml:"link,omitempty"`
+	Authors    []atomPersonOut `xml:"author,omitempty"`
+	Categories []atomCategory  `xml:"category,omitempty"`
+	Rights     string          `xml:"rights,omitempty"`
+	Generator  string          `xml:"generator,omitempty"`
+	Logo       string          `xml:"logo,omitempty"`
+}
+
+type atomEntryHeader struct {
+	ID         string          `xml:"id"`
+	Title      atomTextOut     `xml:"title"`
+	Updated    string          `xml:"updated"`
+	Published  string          `xml:"published,omitempty"`
+	Links      []atomLink      `xml:"link,omitempty"`
+	Authors    []atomPersonOut `xml:"author,omitempty"`
+	Categories []atomCategory  `xml:"category,omitempty"`
+	Summary    *atomTextOut    `xml:"summary,omitempty"`
+}
+
+type atomTextOut struct {
+	Type string `xml:"type,attr,omitempty"`
+	Text string `xml:",chardata"`
+}
+
+type atomPersonOut struct {
+	Name  string `xml:"name"`
+	Email string `xml:"email,omitempty"`
+}
+
+// WriteAtomFeedXML writes the podcast as an Atom 1.0 feed. Items are written
+// as entries with their enclosures as enclosure links, and elements from
+// other namespaces, such as iTunes, are written as extension elements.
+//
+// Atom requires an IRI id and updated time for the feed and each entry. The
+// feed id is the podcast:guid, self link or link, and an entry id is the
+// item's guid, link or enclosure URL. Values which aren't IRIs, such as most
+// guids, are converted to a urn:uuid IRI derived from the value and the feed
+// id. The updated time of the feed is the latest of its lastBuildDate,
+// pubDate and the dates of its items, and an entry's is its pubDate or the
+// feed's updated time. An error is returned, before anything is written, if
+// the feed has no id or date, or an entry has no id.
+//
+// Empty extension values, such as an itunes:image without a URL, are not
+// written.
+func (p *Podcast) WriteAtomFeedXML(w io.Writer) error {
+	pc := p.withLegacyFields()
+	updated, err := pc.atomUpdated()
+	if err != nil {
+		return err
+	}
+	header, err := pc.atomHeader(updated)
+	if err != nil {
+		return err
+	}
+	entryHeaders := make([]atomEntryHeader, 0, len(pc.Items))
+	for _, item := range pc.Items {
+		entryHeader, err := item.atomEntryHeader(header.ID, updated)
+		if err != nil {
+			return err
+		}
+		entryHeaders = append(entryHeaders, entryHeader)
+	}
+
+	_, err = w.Write([]byte(xml.Header))
+	if err != nil {
+		return err
+	}
+
+	namespaces := pc.writeNamespaces()
+	e := xml.NewEncoder(w)
+	fe := newFeedEncoder(e, namespaces)
+
+	start := xml.StartElement{
+		Name: xml.Name{Local: "feed"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: atomNamespaceURL}},
+	}
+	if pc.Language != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xml:lang"}, Value: pc.Language})
+	}
+	for _, ns := range namespaces {
+		if ns.URI != atomNamespaceURL {
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:" + ns.Prefix}, Value: ns.URI})
+		}
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if err := fe.encodeFields(reflect.ValueOf(&header).Elem()); err != nil {
+		return err
+	}
+	if err := fe.encodeNamespacedFields(reflect.ValueOf(pc).Elem(), atomNamespaceURL); err != nil {
+		return err
+	}
+
+	for n, item := range pc.Items {
+		entryStart := xml.StartElement{Name: xml.Name{Local: "entry"}}
+		if err := e.EncodeToken(entryStart); err != nil {
+			return err
+		}
+		if err := fe.encodeFields(reflect.ValueOf(&entryHeaders[n]).Elem()); err != nil {
+			return err
+		}
+		if err := fe.encodeNamespacedFields(reflect.ValueOf(item).Elem(), atomNamespaceURL); err != nil {
+			return err
+		}
+		if err := e.EncodeToken(entryStart.End()); err != nil {
+			return err
+		}
+	}
+
+	if err := e.EncodeToken(start.End()); err != nil {
+		return err
+	}
+	return e.Flush()
+}
+
+func (p *Podcast) atomHeader(updated *Time) (atomHeader, error) {
+	h := atomHeader{
+		Title:      atomTextOut{Text: p.Title},
+		Updated:    updated.marshalISO8601(),
+		Categories: rssCategories(p.Category),
+		Rights:     p.Copyright,
+		Generator:  p.Generator,
+		Logo:       p.ITunesImage.Href,
+	}
+	switch {
+	case p.PodcastGUID != "":
+		h.ID = "urn:uuid:" + p.PodcastGUID
+	case isAbsoluteIRI(p.AtomLink.Href):
+		h.ID = p.AtomLink.Href
+	case isAbsoluteIRI(p.Link):
+		h.ID = p.Link
+	default:
+		return atomHeader{}, errors.New("podcast has no podcast:guid or link to use as its atom id")
+	}
+	if p.Description.Text != "" {
+		h.Subtitle = &atomTextOut{Type: "html", Text: p.Description.Text}
+	}
+	if p.Image != nil && p.Image.URL != "" {
+		h.Logo = p.Image.URL
+	}
+	if p.AtomLink.Href != "" {
+		h.Links = append(h.Links, atomLink{Href: p.AtomLink.Href, Rel: "self", Type: p.AtomLink.Type})
+	}
+	if p.Link != "" {
+		h.Links = append(h.Links, atomLink{Href: p.Link, Rel: "alternate"})
+	}
+
+	var author atomPersonOut
+	author.Email, author.Name = ParseRSSPerson(p.ManagingEditor)
+	if author.Name == "" {
+		author.Name = p.ITunesAuthor
+	}
+	if author.Name == "" && p.ITunesOwner != nil {
+		author.Name, author.Email = p.ITunesOwner.Name, p.ITunesOwner.Email
+	}
+	if author.Name != "" {
+		h.Authors = []atomPersonOut{author}
+	}
+	return h, nil
+}
+
+func (i *Item) atomEntryHeader(feedID string, feedUpdated *Time) (atomEntryHeader, error) {
+	id := strings.TrimSpace(firstNonEmpty(i.GUID.Text, i.Link, i.Enclosure.URL))
+	if id == "" {
+		return atomEntryHeader{}, fmt.Errorf("item '%s' has no guid or link to use as its atom id", i.Title)
+	}
+	if !isAbsoluteIRI(id) {
+		id = derivedAtomID(feedID, id)
+	}
+
+	h := atomEntryHeader{
+		ID:         id,
+		Title:      atomTextOut{Text: i.Title},
+		Updated:    feedUpdated.marshalISO8601(),
+		Categories: rssCategories(i.Category),
+	}
+	if date := i.Date(); date != nil {
+		h.Updated = date.marshalISO8601()
+		h.Published = h.Updated
+	}
+	if i.Link != "" {
+		h.Links = append(h.Links, atomLink{Href: i.Link, Rel: "alternate"})
+	}
+	if i.Enclosure.URL != "" {
+		h.Links = append(h.Links, atomLink{
+			Href:   i.Enclosure.URL,
+			Rel:    "enclosure",
+			Type:   i.Enclosure.Type,
+			Length: i.Enclosure.Length,
+		})
+	}
+	if i.Description != nil && i.Description.Text != "" {
+		h.Summary = &atomTextOut{Type: "html", Text: i.Description.Text}
+	}
+
+	var author atomPersonOut
+	author.Email, author.Name = ParseRSSPerson(i.Author)
+	if author.Name == "" {
+		author.Name = i.ITunesAuthor
+	}
+	if author.Name != "" {
+		h.Authors = []atomPersonOut{author}
+	}
+	return h, nil
+}
+
+// atomUpdated returns the time the podcast was last updated, see
+// WriteAtomFeedXML.
+func (p *Podcast) atomUpdated() (*Time, error) {
+	var latest *Time
+	for _, date := range []Date{p.LastBuildDate, p.PubDate} {
+		if t, ok := date.Value(); ok && (latest == nil || t.After(time.Time(*latest))) {
+			updated := Time(t)
+			latest = &updated
+		}
+	}
+	for _, item := range p.Items {
+		if date := item.Date(); date != nil && (latest == nil || time.Time(*date).After(time.Time(*latest))) {
+			latest = date
+		}
+	}
+	if latest == nil {
+		return nil, errors.New("podcast has no date to use as its atom updated time")
+	}
+	return latest, nil
+}
+
+// isAbsoluteIRI reports whether s is an absolute IRI, as required for Atom
+// ids, rather than e.g. an RSS guid such as "episode-1".
+func isAbsoluteIRI(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && (u.Opaque != "" || u.Host != "" || u.Path != "")
+}
+
+// podcastGUIDNamespace is the UUID namespace used to derive podcast:guid
+// values, see https://podcastindex.org/namespace/1.0#guid
+var podcastGUIDNamespace = [16]byte{0xea, 0xd4, 0xc2, 0x36, 0xbf, 0x58, 0x58, 0xc6, 0xa2, 0xc6, 0xa6, 0xb2, 0x8d, 0x12, 0x8c, 0xb6}
+
+// derivedAtomID returns a urn:uuid IRI for value, an entry id which isn't an
+// IRI, using a version 5 UUID of the feed id and value so that it is the same
+// each time the feed is written.
+func derivedAtomID(feedID, value string) string {
+	h := sha1.New() // #nosec G401 -- required by version 5 UUIDs, not for security
+	h.Write(podcastGUIDNamespace[:])
+	h.Write([]byte(feedID + " " + value))
+	u := h.Sum(nil)[:16]
+	u[6] = u[6]&0x0f | 0x50
+	u[8] = u[8]&0x3f | 0x80
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
+
+func rssCategories(categories []Category) []atomCategory {
+	var r []atomCategory
+	for _, c := range categories {
+		r = append(r, atomCategory{Term: c.Text, Scheme: c.Domain})
+	}
+	return r
+}
+
+var atomKnownElements = newKnownElements(reflect.TypeFor[atomFeed](), reflect.TypeFor[atomFeed](), reflect.TypeFor[atomEntry]())
+
+func decodeAtomFeed(rec *elementRecorder) (*Podcast, error) {
+	rec.channelDepth = 2
+	rec.itemName = "entry"
+	rec.known = atomKnownElements
+
+	var feed atomFeed
+	err := xml.NewTokenDecoder(rec).Decode(&feed)
+	if err != nil {
+		return nil, err
+	}
+	return feed.translate(), nil
+}
+
+func (f *atomFeed) translate() *Podcast {
+	pc := f.xmlFixPodcast.Translate()
+
+	pc.Title = f.Title.Text
+	pc.Description = Description{Text: f.Subtitle.Text}
+	pc.Language = f.Lang
+	pc.Copyright = f.Rights.Text
+	if f.Updated != nil {
+		pc.LastBuildDate = NewDate(time.Time(*f.Updated))
+	}
+	pc.Generator = strings.TrimSpace(f.Generator)
+	if link := findAtomLink(f.Links, "alternate"); link != nil {
+		pc.Link = link.Href
+	}
+	if link := findAtomLink(f.Links, "self"); link != nil {
+		pc.AtomLink = AtomLink{Href: link.Href, Rel: link.Rel, Type: link.Type}
+	}
+	if logo := firstNonEmpty(f.Logo, f.Icon); logo != "" {
+		pc.Image = &Image{URL: logo, Title: pc.Title, Link: pc.Link}
+	}
+	if len(f.Authors) > 0 {
+		pc.ManagingEditor = f.Authors[0].rssPerson()
+		if pc.ITunesAuthor == "" {
+			pc.ITunesAuthor = f.Authors[0].Name
+		}
+	}
+	pc.Category = atomCategories(f.Categories)
+
+	pc.Items = nil
+	for _, e := range f.Entries {
+		pc.Items = append(pc.Items, e.translate())
+	}
+	return pc
+}
+
+func (e *atomEntry) translate() *Item {
+	item := e.xmlFixItem.Translate()
+
+	item.Title = e.Title.Text
+	item.GUID = ItemGUID{Text: e.ID}
+	if desc := firstNonEmpty(e.Summary.Text, e.Content.Text); desc != "" {
+		item.Description = &Description{Text: desc}
+	}
+	item.PubDate = e.Published
+	if item.PubDate == nil {
+		item.PubDate = e.Updated
+	}
+	if link := findAtomLink(e.Links, "alternate"); link != nil {
+		item.Link = link.Href
+	}
+	if link := findAtomLink(e.Links, "enclosure"); link != nil {
+		item.Enclosure = Enclosure{URL: link.Href, Type: link.Type, Length: link.Length}
+	}
+	if len(e.Authors) > 0 {
+		item.Author = e.Authors[0].rssPerson()
+		if item.ITunesAuthor == "" {
+			item.ITunesAuthor = e.Authors[0].Name
+		}
+	}
+	item.Category = atomCategories(e.Categories)
+	return item
+}
+
+// rssPerson formats the person in the form used by RSS, "email (Name)".
+func (p atomPerson) rssPerson() string {
+	name := strings.TrimSpace(p.Name)
+	email := strings.TrimSpace(p.Email)
+	switch {
+	case email == "":
+		return name
+	case name == "":
+		return email
+	default:
+		return email + " (" + name + ")"
+	}
+}
+
+// findAtomLink returns the first link with the given rel, where a link with
+// no rel is an alternate link.
+func findAtomLink(links []atomLink, rel string) *atomLink {
+	for i, l := range links {
+		if l.Rel == rel || (l.Rel == "" && rel == "alternate") {
+			return &links[i]
+		}
+	}
+	return nil
+}
+
+func atomCategories(categories []atomCategory) []Category {
+	var r []Category
+	for _, c := range categories {
+		r = append(r, Category{Domain: c.Scheme, Text: c.Term})
+	}
+	return r
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,217 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+func TestParseFeed_Atom(t *testing.T) {
+	f, err := os.Open("testdata/test-feed-atom.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	podcast, err := gopodcast.NewParser().ParseFeed(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "Test podcast 1", podcast.Title)
+	assertStr(t, "<p>Test podcast description goes here</p>", podcast.Description.Text)
+	assertStr(t, "https://www.example.com", podcast.Link)
+	assertStr(t, "https://www.example.com/feed.atom", podcast.AtomLink.Href)
+	assertStr(t, "self", podcast.AtomLink.Rel)
+	assertStr(t, "application/atom+xml", podcast.AtomLink.Type)
+	assertStr(t, "en-gb", podcast.Language)
+	assertDate(t, "2024-12-27T21:30:00Z", podcast.LastBuildDate)
+	assertStr(t, "© Test copyright", podcast.Copyright)
+	assertStr(t, "Test generator", podcast.Generator)
+	assertStr(t, "https://www.example.com/logo.jpg", podcast.Image.URL)
+	assertStr(t, "author@example.com (Test author)", podcast.ManagingEditor)
+	assertStr(t, "Test author", podcast.ITunesAuthor)
+	assertInt(t, 1, len(podcast.Category))
+	assertStr(t, "Comedy", podcast.Category[0].Text)
+	assertStr(t, "https://www.example.com/categories", podcast.Category[0].Domain)
+
+	// extension elements
+	assertStr(t, "https://www.example.com/image.jpg", podcast.ITunesImage.Href)
+	assertStr(t, "Comedy", podcast.ITunesCategory[0].Text)
+	assertBool(t, true, bool(podcast.ITunesExplicit))
+	assertInt(t, 1, len(podcast.UnknownElements))
+	assertStr(t, "rating", podcast.UnknownElements[0].XMLName.Local)
+	assertTrue(t, len(podcast.Namespaces) > 0)
+
+	assertInt(t, 2, len(podcast.Items))
+	item := podcast.Items[0]
+	assertStr(t, "urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a", item.GUID.Text)
+	assertStr(t, "Test episode 1", item.Title)
+	assertStr(t, "https://www.example.com/episode-1", item.Link)
+	assertStr(t, "https://www.example.com/episode-1.mp3", item.Enclosure.URL)
+	assertStr(t, "audio/mpeg", item.Enclosure.Type)
+	assertInt(t, 1001, int(item.Enclosure.Length))
+	assertStr(t, "2024-12-20T09:00:00Z", time.Time(*item.PubDate).UTC().Format(time.RFC3339))
+	assertStr(t, "Test episode summary", item.Description.Text)
+	assertStr(t, "Episode author", item.Author)
+	assertStr(t, "Episode author", item.ITunesAuthor)
+	assertStr(t, "600", item.ITunesDuration)
+	assertStr(t, "1", item.ITunesEpisode)
+	assertInt(t, 0, len(item.UnknownElements))
+
+	item = podcast.Items[1]
+	assertStr(t, "Test <b>episode</b> 2", item.Title)
+	assertStr(t, "2024-12-27T10:00:00Z", time.Time(*item.PubDate).Format(time.RFC3339))
+	assertStr(t, "<p>Test episode content</p>", item.Description.Text)
+	assertStr(t, "", item.Link)
+}
+
+func TestParseFeed_AtomRequiredValues(t *testing.T) {
+	f, err := os.Open("testdata/test-feed-atom.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	podcast, err := gopodcast.NewParser().ParseFeed(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkRequiredFeedValuesPresent(t, podcast)
+}
+
+func TestWriteAtomFeed(t *testing.T) {
+	podcast := &gopodcast.Podcast{
+		AtomLink:       gopodcast.AtomLink{Href: "https://www.example.com/feed.atom", Rel: "self", Type: "application/atom+xml"},
+		Title:          "Test podcast 1",
+		Description:    gopodcast.Description{Text: "<p>Test description</p>"},
+		Link:           "https://www.example.com",
+		Language:       "en-gb",
+		ITunesCategory: []gopodcast.ITunesCategory{{Text: "Comedy"}},
+		ITunesExplicit: true,
+		ITunesImage:    gopodcast.ITunesImage{Href: "https://www.example.com/image.jpg"},
+		ITunesAuthor:   "Test author",
+		Copyright:      "Test copyright",
+		ManagingEditor: "author@example.com (Test author)",
+		LastBuildDate:  dateFromStr("2024-12-27T21:30:00"),
+		Category:       []gopodcast.Category{{Text: "Comedy", Domain: "https://www.example.com/categories"}},
+		Items: []*gopodcast.Item{
+			{
+				Title: "Test episode 1",
+				Enclosure: gopodcast.Enclosure{
+					URL:    "https://www.example.com/episode-1.mp3",
+					Type:   "audio/mpeg",
+					Length: 1001,
+				},
+				GUID:           gopodcast.ItemGUID{Text: "urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a"},
+				Link:           "https://www.example.com/episode-1",
+				PubDate:        timeFromStr("2024-12-20T10:00:00"),
+				Description:    &gopodcast.Description{Text: "Test episode summary"},
+				ITunesDuration: "600",
+				ITunesAuthor:   "Episode author",
+			},
+			{
+				Title: "Test episode 2",
+				Enclosure: gopodcast.Enclosure{
+					URL:  "https://www.example.com/episode-2.mp3",
+					Type: "audio/mpeg",
+				},
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	err := podcast.WriteAtomFeedXML(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp, err := os.ReadFile("testdata/test-feed-write-atom.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, strings.TrimSpace(string(exp)), strings.TrimSpace(buf.String()))
+
+	// and can be parsed again
+	written, err := gopodcast.NewParser().ParseFeed(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, "Test podcast 1", written.Title)
+	assertStr(t, "<p>Test description</p>", written.Description.Text)
+	assertStr(t, "https://www.example.com/image.jpg", written.ITunesImage.Href)
+	assertInt(t, 2, len(written.Items))
+	assertStr(t, "https://www.example.com/episode-1.mp3", written.Items[0].Enclosure.URL)
+	assertStr(t, "600", written.Items[0].ITunesDuration)
+	assertStr(t, "Episode author", written.Items[0].ITunesAuthor)
+	assertStr(t, "https://www.example.com/episode-2.mp3", written.Items[1].GUID.Text)
+	assertStr(t, "2024-12-27T21:30:00Z", time.Time(*written.Items[1].PubDate).Format(time.RFC3339))
+}
+
+func TestWriteAtomFeed_IDs(t *testing.T) {
+	podcast := &gopodcast.Podcast{
+		AtomLink: gopodcast.AtomLink{Href: "https://www.example.com/feed.atom", Rel: "self"},
+		Title:    "Test podcast 1",
+		PubDate:  dateFromStr("2024-12-27T21:30:00"),
+		Items: []*gopodcast.Item{
+			{Title: "Test episode 1", GUID: gopodcast.ItemGUID{Text: "g"}},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := podcast.WriteAtomFeedXML(buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	assertTrue(t, strings.Contains(out, `<id>https://www.example.com/feed.atom</id>`))
+	// guids which aren't IRIs are converted to one, the same each time
+	assertTrue(t, strings.Contains(out, `<entry><id>urn:uuid:bdb721d1-d770-55e1-86ab-4007771d9ca0</id>`))
+	buf2 := &bytes.Buffer{}
+	if err := podcast.WriteAtomFeedXML(buf2); err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, out, buf2.String())
+
+	// empty extension values aren't written
+	assertTrue(t, !strings.Contains(out, `<itunes:image`))
+	assertTrue(t, !strings.Contains(out, `<itunes:explicit>`))
+
+	podcast.Items[0].GUID.Text = ""
+	buf.Reset()
+	err := podcast.WriteAtomFeedXML(buf)
+	assertNotNil(t, err)
+	assertStr(t, "item 'Test episode 1' has no guid or link to use as its atom id", err.Error())
+	assertStr(t, "", buf.String())
+
+	podcast.AtomLink.Href = ""
+	err = podcast.WriteAtomFeedXML(buf)
+	assertNotNil(t, err)
+	assertStr(t, "podcast has no podcast:guid or link to use as its atom id", err.Error())
+}
+
+func TestWriteAtomFeed_Updated(t *testing.T) {
+	podcast := &gopodcast.Podcast{
+		Link:          "https://www.example.com",
+		Title:         "Test podcast 1",
+		LastBuildDate: dateFromStr("2024-12-20T10:00:00"),
+		Items: []*gopodcast.Item{
+			{Title: "Test episode 1", Link: "https://www.example.com/1", PubDate: timeFromStr("2024-12-27T21:30:00")},
+		},
+	}
+
+	// the latest channel or item date is used
+	buf := &bytes.Buffer{}
+	if err := podcast.WriteAtomFeedXML(buf); err != nil {
+		t.Fatal(err)
+	}
+	assertTrue(t, strings.Contains(buf.String(), `<title>Test podcast 1</title><updated>2024-12-27T21:30:00Z</updated>`))
+
+	podcast.LastBuildDate = ""
+	podcast.Items[0].PubDate = nil
+	err := podcast.WriteAtomFeedXML(&bytes.Buffer{})
+	assertNotNil(t, err)
+	assertStr(t, "podcast has no date to use as its atom updated time", err.Error())
+}
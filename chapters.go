@@ -0,0 +1,85 @@
+package gopodcast
+
+import (
+	"encoding/xml"
+	"math"
+	"time"
+)
+
+// JSONChapters is the Podcasting 2.0 JSON chapters format, as linked to by
+// podcast:chapters. See
+// https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/examples/chapters/jsonChapters.md
+type JSONChapters struct {
+	Version  string        `json:"version"`
+	Chapters []JSONChapter `json:"chapters"`
+}
+
+// JSONChapter is a chapter in JSONChapters. Times are in seconds.
+type JSONChapter struct {
+	StartTime float64 `json:"startTime"`
+	EndTime   float64 `json:"endTime,omitempty"`
+	Title     string  `json:"title,omitempty"`
+	Img       string  `json:"img,omitempty"`
+	URL       string  `json:"url,omitempty"`
+	TOC       *bool   `json:"toc,omitempty"`
+}
+
+const (
+	jsonChaptersVersion = "1.2.0"
+	pscChaptersVersion  = "1.2"
+)
+
+// JSONChapters converts Podlove Simple Chapters to the Podcasting 2.0 JSON
+// chapters format.
+func (c *PSCChapters) JSONChapters() *JSONChapters {
+	jc := &JSONChapters{Version: jsonChaptersVersion, Chapters: []JSONChapter{}}
+	for _, ch := range c.Chapters {
+		jc.Chapters = append(jc.Chapters, JSONChapter{
+			StartTime: ch.Start.Seconds(),
+			Title:     ch.Title,
+			Img:       ch.Image,
+			URL:       ch.Href,
+		})
+	}
+	return jc
+}
+
+// PSCChapters converts Podcasting 2.0 JSON chapters to Podlove Simple
+// Chapters. End times, and whether chapters are in the table of contents,
+// are not supported by Podlove Simple Chapters and are dropped.
+func (c *JSONChapters) PSCChapters() *PSCChapters {
+	pc := &PSCChapters{Version: pscChaptersVersion}
+	for _, ch := range c.Chapters {
+		start := time.Duration(math.Round(ch.StartTime*1000)) * time.Millisecond
+		pc.Chapters = append(pc.Chapters, PSCChapter{
+			Start: start,
+			Title: ch.Title,
+			Href:  ch.URL,
+			Image: ch.Img,
+		})
+	}
+	return pc
+}
+
+// pscChapter is PSCChapter with its start time in normal play time, as it
+// is parsed and written.
+type pscChapter struct {
+	Start NormalPlayTime `xml:"start,attr" json:"start"`
+	Title string         `xml:"title,attr" json:"title,omitempty"`
+	Href  string         `xml:"href,attr,omitempty" json:"href,omitempty"`
+	Image string         `xml:"image,attr,omitempty" json:"image,omitempty"`
+}
+
+func (c *PSCChapter) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var pc pscChapter
+	if err := d.DecodeElement(&pc, &start); err != nil {
+		return err
+	}
+	*c = PSCChapter{Start: time.Duration(pc.Start), Title: pc.Title, Href: pc.Href, Image: pc.Image}
+	return nil
+}
+
+func (c PSCChapter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	pc := pscChapter{Start: NormalPlayTime(c.Start), Title: c.Title, Href: c.Href, Image: c.Image}
+	return e.EncodeElement(pc, start)
+}
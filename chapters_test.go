@@ -0,0 +1,147 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+const pscFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss xmlns:psc="http://podlove.org/simple-chapters" version="2.0">
+  <channel>
+    <title>Test podcast 1</title>
+    <item>
+      <title>Test episode 1</title>
+      <psc:chapters version="1.2">
+        <psc:chapter start="0" title="Intro"/>
+        <psc:chapter start="01:30.5" title="Topic 1" href="https://www.example.com/1" image="https://www.example.com/1.jpg"/>
+        <psc:chapter start="01:02:03.250" title="Topic 2"/>
+      </psc:chapters>
+    </item>
+  </channel>
+</rss>`
+
+func TestParseFeed_PSCChapters(t *testing.T) {
+	podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(pscFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item := podcast.Items[0]
+	assertStr(t, "1.2", item.PSCChapters.Version)
+	chapters := item.PSCChapters.Chapters
+	assertInt(t, 3, len(chapters))
+	assertStr(t, "0s", chapters[0].Start.String())
+	assertStr(t, "Intro", chapters[0].Title)
+	assertStr(t, "1m30.5s", chapters[1].Start.String())
+	assertStr(t, "https://www.example.com/1", chapters[1].Href)
+	assertStr(t, "https://www.example.com/1.jpg", chapters[1].Image)
+	assertStr(t, "1h2m3.25s", chapters[2].Start.String())
+	assertInt(t, 0, len(item.UnknownElements))
+}
+
+func TestWriteFeed_PSCChapters(t *testing.T) {
+	podcast := &gopodcast.Podcast{
+		Title: "Test title",
+		Items: []*gopodcast.Item{
+			{
+				Title: "A podcast 1",
+				PSCChapters: &gopodcast.PSCChapters{
+					Version: "1.2",
+					Chapters: []gopodcast.PSCChapter{
+						{Start: 0, Title: "Intro"},
+						{Start: 90500 * time.Millisecond, Title: "Topic 1", Href: "https://www.example.com/1"},
+					},
+				},
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	err := podcast.WriteFeedXML(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	assertTrue(t, strings.Contains(out, `xmlns:psc="http://podlove.org/simple-chapters"`))
+	assertTrue(t, strings.Contains(out, `<psc:chapters version="1.2"><psc:chapter start="00:00:00" title="Intro"></psc:chapter><psc:chapter start="00:01:30.500" title="Topic 1" href="https://www.example.com/1"></psc:chapter></psc:chapters>`))
+}
+
+func TestNormalPlayTime(t *testing.T) {
+	testCases := map[string]struct {
+		in  string
+		exp time.Duration
+		out string
+	}{
+		"seconds":              {in: "3", exp: 3 * time.Second, out: "00:00:03"},
+		"minutes":              {in: "02:03", exp: 2*time.Minute + 3*time.Second, out: "00:02:03"},
+		"hours":                {in: "1:02:03", exp: time.Hour + 2*time.Minute + 3*time.Second, out: "01:02:03"},
+		"milliseconds":         {in: "00:00:01.5", exp: 1500 * time.Millisecond, out: "00:00:01.500"},
+		"long hours":           {in: "100:00:00.001", exp: 100*time.Hour + time.Millisecond, out: "100:00:00.001"},
+		"surrounding spaces":   {in: " 01:00 ", exp: time.Minute, out: "00:01:00"},
+		"unrounded fractional": {in: "1.0004", exp: time.Second + 400*time.Microsecond, out: "00:00:01"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var npt gopodcast.NormalPlayTime
+			err := npt.UnmarshalText([]byte(tc.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertStr(t, tc.exp.String(), time.Duration(npt).String())
+
+			out, err := npt.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertStr(t, tc.out, string(out))
+		})
+	}
+}
+
+func TestNormalPlayTime_Invalid(t *testing.T) {
+	for _, in := range []string{"", "abc", "1:2:3:4", "-1", "1.x"} {
+		var npt gopodcast.NormalPlayTime
+		err := npt.UnmarshalText([]byte(in))
+		if err == nil {
+			t.Fatalf("expected error for '%s'", in)
+		}
+	}
+}
+
+func TestPSCChaptersToJSON(t *testing.T) {
+	psc := &gopodcast.PSCChapters{
+		Chapters: []gopodcast.PSCChapter{
+			{Start: 0, Title: "Intro"},
+			{Start: 90500 * time.Millisecond, Title: "Topic 1", Href: "https://www.example.com/1", Image: "https://www.example.com/1.jpg"},
+		},
+	}
+
+	b, err := json.Marshal(psc.JSONChapters())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, `{"version":"1.2.0","chapters":[{"startTime":0,"title":"Intro"},{"startTime":90.5,"title":"Topic 1","img":"https://www.example.com/1.jpg","url":"https://www.example.com/1"}]}`, string(b))
+}
+
+func TestJSONChaptersToPSC(t *testing.T) {
+	var jc gopodcast.JSONChapters
+	err := json.Unmarshal([]byte(`{"version":"1.2.0","chapters":[{"startTime":0,"title":"Intro"},{"startTime":90.5,"endTime":120,"title":"Topic 1","img":"https://www.example.com/1.jpg","url":"https://www.example.com/1","toc":false}]}`), &jc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	psc := jc.PSCChapters()
+	assertStr(t, "1.2", psc.Version)
+	assertInt(t, 2, len(psc.Chapters))
+	assertStr(t, "Intro", psc.Chapters[0].Title)
+	assertStr(t, "1m30.5s", psc.Chapters[1].Start.String())
+	assertStr(t, "https://www.example.com/1", psc.Chapters[1].Href)
+	assertStr(t, "https://www.example.com/1.jpg", psc.Chapters[1].Image)
+}
@@ -0,0 +1,260 @@
+package gopodcast
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// CharsetReader returns a reader which converts input from the given
+// charset to UTF-8, with the same signature as xml.Decoder.CharsetReader.
+// Feeds are parsed using it, so that feeds declared in non-UTF-8 charsets
+// can be read without any dependencies.
+//
+// Supported charsets are windows-1252, ISO-8859-15, Shift_JIS and EUC-JP,
+// along with their common aliases. As in web browsers, ISO-8859-1 and
+// US-ASCII are read as windows-1252, which is a superset of both in practice.
+// Bytes which aren't valid in the charset, and JIS X 0212 characters in
+// EUC-JP, are replaced with U+FFFD.
+func CharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "utf-8", "utf8", "unicode-1-1-utf-8":
+		return input, nil
+	case "windows-1252", "cp1252", "x-cp1252", "iso-8859-1", "iso8859-1", "iso_8859-1", "iso_8859-1:1987",
+		"latin1", "l1", "iso-ir-100", "cp819", "ibm819", "csisolatin1", "us-ascii", "ascii", "ansi_x3.4-1968":
+		return newDecodingReader(input, decodeSingleByte(&windows1252)), nil
+	case "iso-8859-15", "iso8859-15", "iso_8859-15", "iso885915", "latin9", "latin-9", "l9", "csisolatin9":
+		return newDecodingReader(input, decodeSingleByte(iso885915)), nil
+	case "shift_jis", "shift-jis", "sjis", "x-sjis", "ms_kanji", "csshiftjis", "windows-31j", "cp932", "ms932":
+		return newDecodingReader(input, decodeShiftJIS), nil
+	case "euc-jp", "eucjp", "x-euc-jp", "cseucpkdfmtjapanese":
+		return newDecodingReader(input, decodeEUCJP), nil
+	}
+	return nil, fmt.Errorf("unsupported charset '%s'", charset)
+}
+
+// newFeedDecoder returns a decoder for a feed read from r. The feed's charset
+// is taken from its byte order mark or XML declaration, or otherwise from
+// charset if set, such as from an HTTP Content-Type header. Servers often
+// send a default charset whatever the feed is in, so a charset in the feed
+// itself takes precedence. An unsupported charset is ignored.
+func newFeedDecoder(r io.Reader, charset string) *xml.Decoder {
+	if charset != "" {
+		br := bufio.NewReader(r)
+		r = br
+		if !hasDeclaredCharset(br) {
+			if cr, err := CharsetReader(charset, br); err == nil {
+				d := xml.NewDecoder(cr)
+				// the feed is already UTF-8
+				d.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+					return input, nil
+				}
+				return d
+			}
+		}
+	}
+
+	d := xml.NewDecoder(r)
+	d.CharsetReader = CharsetReader
+	return d
+}
+
+// maxXMLDeclarationLen is the number of bytes read to find the encoding in an
+// XML declaration, which is much longer than any real declaration.
+const maxXMLDeclarationLen = 256
+
+var xmlDeclarationEncoding = regexp.MustCompile(`^<\?xml\s[^>]*?\bencoding\s*=\s*["'][^"']+["']`)
+
+// hasDeclaredCharset reports whether the feed read by r starts with a byte
+// order mark, or an XML declaration with an encoding.
+func hasDeclaredCharset(r *bufio.Reader) bool {
+	b, _ := r.Peek(maxXMLDeclarationLen)
+	for _, bom := range [][]byte{{0xef, 0xbb, 0xbf}, {0xfe, 0xff}, {0xff, 0xfe}} {
+		if bytes.HasPrefix(b, bom) {
+			return true
+		}
+	}
+	return xmlDeclarationEncoding.Match(b)
+}
+
+// contentTypeCharset returns the charset parameter of a Content-Type header,
+// or an empty string if it has none.
+func contentTypeCharset(contentType string) string {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return params["charset"]
+}
+
+// decodingReader converts text to UTF-8, reading one character at a time
+// using decode.
+type decodingReader struct {
+	r      *bufio.Reader
+	decode func(r *bufio.Reader) (rune, error)
+	buf    []byte
+	err    error
+}
+
+func newDecodingReader(r io.Reader, decode func(r *bufio.Reader) (rune, error)) *decodingReader {
+	return &decodingReader{r: bufio.NewReader(r), decode: decode}
+}
+
+func (dr *decodingReader) Read(p []byte) (int, error) {
+	for len(dr.buf) < len(p) && dr.err == nil {
+		var c rune
+		c, dr.err = dr.decode(dr.r)
+		if dr.err == nil {
+			dr.buf = utf8.AppendRune(dr.buf, c)
+		}
+	}
+	if len(dr.buf) == 0 {
+		return 0, dr.err
+	}
+	n := copy(p, dr.buf)
+	dr.buf = dr.buf[:copy(dr.buf, dr.buf[n:])]
+	return n, nil
+}
+
+// decodeSingleByte returns a decode function for a charset which is ASCII
+// up to 0x7F, where high maps the bytes from 0x80 to Unicode.
+func decodeSingleByte(high *[128]rune) func(r *bufio.Reader) (rune, error) {
+	return func(r *bufio.Reader) (rune, error) {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if b < 0x80 {
+			return rune(b), nil
+		}
+		return high[b-0x80], nil
+	}
+}
+
+// decodeShiftJIS decodes a Shift_JIS character, as described by the WHATWG
+// Encoding Standard.
+func decodeShiftJIS(r *bufio.Reader) (rune, error) {
+	lead, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case lead <= 0x80:
+		return rune(lead), nil
+	case lead >= 0xA1 && lead <= 0xDF:
+		// half width katakana
+		return 0xFF61 - 0xA1 + rune(lead), nil
+	case lead == 0xA0 || lead > 0xFC:
+		return utf8.RuneError, nil
+	}
+
+	trail, err := r.ReadByte()
+	if err == io.EOF {
+		return utf8.RuneError, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if (trail >= 0x40 && trail <= 0x7E) || (trail >= 0x80 && trail <= 0xFC) {
+		leadOffset, trailOffset := 0x81, 0x40
+		if lead >= 0xA0 {
+			leadOffset = 0xC1
+		}
+		if trail >= 0x7F {
+			trailOffset = 0x41
+		}
+		if c := jis0208Rune((int(lead)-leadOffset)*188 + int(trail) - trailOffset); c != utf8.RuneError {
+			return c, nil
+		}
+	}
+	if trail < 0x80 {
+		// ASCII isn't part of an invalid character, so is read again
+		_ = r.UnreadByte()
+	}
+	return utf8.RuneError, nil
+}
+
+// decodeEUCJP decodes an EUC-JP character, as described by the WHATWG
+// Encoding Standard, except that JIS X 0212 characters aren't supported.
+func decodeEUCJP(r *bufio.Reader) (rune, error) {
+	lead, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if lead < 0x80 {
+		return rune(lead), nil
+	}
+	if lead != 0x8E && lead != 0x8F && (lead < 0xA1 || lead > 0xFE) {
+		return utf8.RuneError, nil
+	}
+
+	trail, err := r.ReadByte()
+	if err == io.EOF {
+		return utf8.RuneError, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case lead == 0x8E && trail >= 0xA1 && trail <= 0xDF:
+		// half width katakana
+		return 0xFF61 - 0xA1 + rune(trail), nil
+	case lead == 0x8F && trail >= 0xA1 && trail <= 0xFE:
+		// JIS X 0212, which is three bytes
+		if b, err := r.ReadByte(); err == nil && b < 0x80 {
+			_ = r.UnreadByte()
+		}
+		return utf8.RuneError, nil
+	case lead >= 0xA1 && trail >= 0xA1 && trail <= 0xFE:
+		if c := jis0208Rune((int(lead)-0xA1)*94 + int(trail) - 0xA1); c != utf8.RuneError {
+			return c, nil
+		}
+	}
+	if trail < 0x80 {
+		// ASCII isn't part of an invalid character, so is read again
+		_ = r.UnreadByte()
+	}
+	return utf8.RuneError, nil
+}
+
+func jis0208Rune(pointer int) rune {
+	if pointer < 0 || pointer >= len(jis0208) || jis0208[pointer] == 0 {
+		return utf8.RuneError
+	}
+	return rune(jis0208[pointer])
+}
+
+// windows1252 maps the bytes from 0x80 of windows-1252 to Unicode. Bytes
+// which are undefined map to the C1 control character of the same value.
+var windows1252 = [128]rune{
+	0x20AC, 0x0081, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021, 0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0x008D, 0x017D, 0x008F,
+	0x0090, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014, 0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0x009D, 0x017E, 0x0178,
+	0x00A0, 0x00A1, 0x00A2, 0x00A3, 0x00A4, 0x00A5, 0x00A6, 0x00A7, 0x00A8, 0x00A9, 0x00AA, 0x00AB, 0x00AC, 0x00AD, 0x00AE, 0x00AF,
+	0x00B0, 0x00B1, 0x00B2, 0x00B3, 0x00B4, 0x00B5, 0x00B6, 0x00B7, 0x00B8, 0x00B9, 0x00BA, 0x00BB, 0x00BC, 0x00BD, 0x00BE, 0x00BF,
+	0x00C0, 0x00C1, 0x00C2, 0x00C3, 0x00C4, 0x00C5, 0x00C6, 0x00C7, 0x00C8, 0x00C9, 0x00CA, 0x00CB, 0x00CC, 0x00CD, 0x00CE, 0x00CF,
+	0x00D0, 0x00D1, 0x00D2, 0x00D3, 0x00D4, 0x00D5, 0x00D6, 0x00D7, 0x00D8, 0x00D9, 0x00DA, 0x00DB, 0x00DC, 0x00DD, 0x00DE, 0x00DF,
+	0x00E0, 0x00E1, 0x00E2, 0x00E3, 0x00E4, 0x00E5, 0x00E6, 0x00E7, 0x00E8, 0x00E9, 0x00EA, 0x00EB, 0x00EC, 0x00ED, 0x00EE, 0x00EF,
+	0x00F0, 0x00F1, 0x00F2, 0x00F3, 0x00F4, 0x00F5, 0x00F6, 0x00F7, 0x00F8, 0x00F9, 0x00FA, 0x00FB, 0x00FC, 0x00FD, 0x00FE, 0x00FF,
+}
+
+// iso885915 maps the bytes from 0x80 of ISO-8859-15 to Unicode, which is
+// ISO-8859-1 with the euro sign and some French and Finnish letters.
+var iso885915 = func() *[128]rune {
+	var high [128]rune
+	for i := range high {
+		high[i] = rune(0x80 + i)
+	}
+	for b, c := range map[byte]rune{
+		0xA4: 0x20AC, 0xA6: 0x0160, 0xA8: 0x0161, 0xB4: 0x017D,
+		0xB8: 0x017E, 0xBC: 0x0152, 0xBD: 0x0153, 0xBE: 0x0178,
+	} {
+		high[b-0x80] = c
+	}
+	return &high
+}()
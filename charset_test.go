@@ -0,0 +1,150 @@
+package gopodcast_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+func TestParseFeed_Charsets(t *testing.T) {
+	testCases := []struct {
+		charset string
+		title   string
+		exp     string
+	}{
+		{"ISO-8859-1", "Caf\xe9 cr\xe8me", "Café crème"},
+		{"windows-1252", "\x93Quoted\x94 \x80 5", "“Quoted” € 5"},
+		{"ISO-8859-15", "\xa4 \xbd", "€ œ"},
+		{"US-ASCII", "Plain text", "Plain text"},
+		{"Shift_JIS", "\x93\xfa\x96{\x82\xcc\x83|\x83b\x83h\x83L\x83\x83\x83X\x83g \xb6\xc0\xb6\xc5 \x87@", "日本のポッドキャスト ｶﾀｶﾅ ①"},
+		{"EUC-JP", "\xc6\xfc\xcb\xdc\xa4\xce\xa5\xdd\xa5\xc3\xa5\xc9\xa5\xad\xa5\xe3\xa5\xb9\xa5\xc8 \x8e\xb6\x8e\xc0\x8e\xb6\x8e\xc5", "日本のポッドキャスト ｶﾀｶﾅ"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.charset, func(t *testing.T) {
+			podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(charsetTestFeed(tc.charset, tc.title)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertStr(t, tc.exp, podcast.Title)
+			assertStr(t, tc.exp, podcast.Items[0].Title)
+		})
+	}
+}
+
+func TestParseFeed_UnsupportedCharset(t *testing.T) {
+	_, err := gopodcast.NewParser().ParseFeed(strings.NewReader(charsetTestFeed("KOI8-R", "Test podcast 1")))
+	assertNotNil(t, err)
+	assertTrue(t, strings.Contains(err.Error(), "unsupported charset 'KOI8-R'"))
+}
+
+func TestParseFeedStream_Charset(t *testing.T) {
+	podcast, items, err := gopodcast.NewParser().ParseFeedStream(strings.NewReader(charsetTestFeed("Shift_JIS", "\x91\xe61\x89\xf1")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, "第1回", podcast.Title)
+	for item, err := range items {
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertStr(t, "第1回", item.Title)
+	}
+}
+
+func TestParseFeedFromURL_ContentTypeCharset(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contentType string
+		feed        string
+	}{
+		{
+			name:        "declaration takes precedence",
+			contentType: "application/rss+xml; charset=Shift_JIS",
+			feed:        charsetTestFeed("EUC-JP", "\xc2\xe81\xb2\xf3"),
+		},
+		{
+			name:        "mislabelled utf-8",
+			contentType: "application/rss+xml; charset=ISO-8859-1",
+			feed:        charsetTestFeed("UTF-8", "第1回"),
+		},
+		{
+			name:        "byte order mark takes precedence",
+			contentType: "text/xml; charset=us-ascii",
+			feed:        "\xef\xbb\xbf" + charsetTestFeed("", "第1回"),
+		},
+		{
+			name:        "without declaration",
+			contentType: "application/rss+xml; charset=euc-jp",
+			feed:        charsetTestFeed("", "\xc2\xe81\xb2\xf3"),
+		},
+		{
+			name:        "unsupported uses declaration",
+			contentType: "application/rss+xml; charset=x-unknown",
+			feed:        charsetTestFeed("Shift_JIS", "\x91\xe61\x89\xf1"),
+		},
+		{
+			name:        "no charset uses declaration",
+			contentType: "application/rss+xml",
+			feed:        charsetTestFeed("EUC-JP", "\xc2\xe81\xb2\xf3"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tc.contentType)
+				_, _ = io.WriteString(w, tc.feed)
+			}))
+			defer srv.Close()
+
+			podcast, err := gopodcast.NewParser().ParseFeedFromURL(context.Background(), srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertStr(t, "第1回", podcast.Title)
+		})
+	}
+}
+
+func TestCharsetReader_InvalidBytes(t *testing.T) {
+	testCases := []struct {
+		charset string
+		input   string
+		exp     string
+	}{
+		{"Shift_JIS", "\x81<a>\xa0", "�<a>�"},
+		{"Shift_JIS", "a\x88", "a�"},
+		{"EUC-JP", "\xa1<a>\xff", "�<a>�"},
+		{"EUC-JP", "\x8f\xa2\xaf<a>", "�<a>"},
+	}
+
+	for _, tc := range testCases {
+		r, err := gopodcast.CharsetReader(tc.charset, strings.NewReader(tc.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertStr(t, tc.exp, string(b))
+	}
+}
+
+// charsetTestFeed returns a feed declared in the given charset, if set, with
+// title, already encoded in the charset, as the title of the podcast and its
+// item.
+func charsetTestFeed(charset, title string) string {
+	decl := `<?xml version="1.0"?>`
+	if charset != "" {
+		decl = `<?xml version="1.0" encoding="` + charset + `"?>`
+	}
+	return decl + `<rss version="2.0"><channel><title>` + title + `</title>` +
+		`<item><title>` + title + `</title></item></channel></rss>`
+}
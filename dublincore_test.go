@@ -0,0 +1,143 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+const dublinCoreFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:sy="http://purl.org/rss/1.0/modules/syndication/" version="2.0">
+  <channel>
+    <title>Test podcast 1</title>
+    <language>en-gb</language>
+    <dc:creator>Test creator</dc:creator>
+    <dc:date>2024-12-27T21:30:00+01:00</dc:date>
+    <dc:language>en</dc:language>
+    <sy:updatePeriod>hourly</sy:updatePeriod>
+    <sy:updateFrequency>2</sy:updateFrequency>
+    <sy:updateBase>2000-01-01T12:00+00:00</sy:updateBase>
+    <item>
+      <title>Test episode 1</title>
+      <dc:creator>Episode creator</dc:creator>
+      <dc:date>2024-12-20</dc:date>
+    </item>
+    <item>
+      <title>Test episode 2</title>
+      <pubDate>Fri, 27 Dec 2024 10:00:00 GMT</pubDate>
+      <itunes:author xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">iTunes author</itunes:author>
+      <dc:creator>Episode creator</dc:creator>
+      <dc:date>2024-12-20</dc:date>
+    </item>
+  </channel>
+</rss>`
+
+func TestParseFeed_DublinCore(t *testing.T) {
+	podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(dublinCoreFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "en-gb", podcast.Language)
+	assertStr(t, "Test creator", podcast.DCCreator)
+	assertDate(t, "2024-12-27T20:30:00Z", podcast.DCDate)
+	assertStr(t, "en", podcast.DCLanguage)
+	assertStr(t, "hourly", podcast.SyUpdatePeriod)
+	assertInt(t, 2, *podcast.SyUpdateFrequency)
+	assertDate(t, "2000-01-01T12:00:00Z", podcast.SyUpdateBase)
+	assertInt(t, 0, len(podcast.UnknownElements))
+
+	item := podcast.Items[0]
+	assertStr(t, "Episode creator", item.DCCreator)
+	assertStr(t, "2024-12-20T00:00:00Z", time.Time(*item.DCDate).Format(time.RFC3339))
+	assertInt(t, 0, len(item.UnknownElements))
+}
+
+func TestItemDateAndCreator(t *testing.T) {
+	podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(dublinCoreFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// falls back to dc values
+	item := podcast.Items[0]
+	assertStr(t, "2024-12-20T00:00:00Z", time.Time(*item.Date()).Format(time.RFC3339))
+	assertStr(t, "Episode creator", item.Creator())
+
+	// prefers pubDate and itunes:author
+	item = podcast.Items[1]
+	assertStr(t, "2024-12-27T10:00:00Z", time.Time(*item.Date()).UTC().Format(time.RFC3339))
+	assertStr(t, "iTunes author", item.Creator())
+
+	item = &gopodcast.Item{}
+	assertNil(t, item.Date())
+	assertStr(t, "", item.Creator())
+}
+
+func TestWriteFeed_DublinCore(t *testing.T) {
+	podcast := &gopodcast.Podcast{
+		Title:          "Test title",
+		PubDate:        dateFromStr("2024-12-27T21:30:00"),
+		DCDate:         gopodcast.NewISO8601Date(time.Time(*timeFromStr("2024-12-27T21:30:00"))),
+		SyUpdatePeriod: "daily",
+		Items: []*gopodcast.Item{
+			{Title: "A podcast 1", DCCreator: "Test creator", DCDate: timeFromStr("2024-12-20T10:00:00")},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	err := podcast.WriteFeedXML(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	assertTrue(t, strings.Contains(out, `xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:sy="http://purl.org/rss/1.0/modules/syndication/"`))
+	assertTrue(t, strings.Contains(out, `<dc:date>2024-12-27T21:30:00Z</dc:date>`))
+	assertTrue(t, strings.Contains(out, `<pubDate>Fri, 27 Dec 2024 21:30:00 UTC</pubDate>`))
+	assertTrue(t, strings.Contains(out, `<sy:updatePeriod>daily</sy:updatePeriod>`))
+	assertTrue(t, strings.Contains(out, `<dc:creator>Test creator</dc:creator><dc:date>2024-12-20T10:00:00Z</dc:date>`))
+}
+
+func TestParseFeed_InvalidDublinCoreDate(t *testing.T) {
+	feed := strings.Replace(dublinCoreFeed, `<dc:date>2024-12-27T21:30:00+01:00</dc:date>`, `<dc:date>last tuesday</dc:date>`, 1)
+	podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "last tuesday", string(podcast.DCDate))
+	_, ok := podcast.DCDate.Value()
+	assertBool(t, false, ok)
+
+	// and is written as it was parsed
+	buf := &bytes.Buffer{}
+	if err := podcast.WriteFeedXML(buf); err != nil {
+		t.Fatal(err)
+	}
+	assertTrue(t, strings.Contains(buf.String(), `<dc:date>last tuesday</dc:date>`))
+}
+
+func TestParseFeed_DublinCoreDateFormats(t *testing.T) {
+	testCases := map[string]string{
+		"2024-12-20T10:30:00.5+01:00": "2024-12-20T09:30:00Z",
+		" 2024-12-20T10:30Z ":         "2024-12-20T10:30:00Z",
+		"2024-12-20T10:30":            "2024-12-20T10:30:00Z",
+		"2024-12-20 10:30:00":         "2024-12-20T10:30:00Z",
+		"2024-12":                     "2024-12-01T00:00:00Z",
+	}
+
+	for date, exp := range testCases {
+		t.Run(date, func(t *testing.T) {
+			feed := strings.Replace(dublinCoreFeed, `<dc:date>2024-12-20</dc:date>`, `<dc:date>`+date+`</dc:date>`, 1)
+			podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(feed))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertStr(t, exp, time.Time(*podcast.Items[0].DCDate).UTC().Format(time.RFC3339))
+		})
+	}
+}
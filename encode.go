@@ -0,0 +1,418 @@
+package gopodcast
+
+import (
+	"encoding/xml"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// feedEncoder writes feed structs as XML. Struct fields are written in the
+// same way as encoding/xml, but unknown elements and attributes are written
+// in their original positions with their original namespace prefixes.
+type feedEncoder struct {
+	e *xml.Encoder
+	// prefixes maps namespace URLs to prefixes
+	prefixes map[string]string
+}
+
+// newFeedEncoder returns a feedEncoder which writes elements using the
+// prefixes of the namespaces always declared in emptyFeed, and those given.
+func newFeedEncoder(e *xml.Encoder, namespaces []Namespace) *feedEncoder {
+	prefixes := map[string]string{xmlNamespaceURL: "xml"}
+	for _, ns := range builtinNamespaces[:numFixedNamespaces] {
+		prefixes[ns.URI] = ns.Prefix
+	}
+	for _, ns := range namespaces {
+		prefixes[ns.URI] = ns.Prefix
+	}
+	return &feedEncoder{e: e, prefixes: prefixes}
+}
+
+// encodeStruct writes v, which must be an addressable struct, as an element
+// with the given start.
+func (fe *feedEncoder) encodeStruct(start xml.StartElement, v reflect.Value) error {
+	se, err := fe.beginStruct(start, v)
+	if err != nil {
+		return err
+	}
+	return se.end()
+}
+
+// structEncoder writes a struct in stages, so that a field can be written by
+// the caller in between, such as the items of a streamed feed.
+type structEncoder struct {
+	fe      *feedEncoder
+	start   xml.StartElement
+	v       reflect.Value
+	info    *encodeInfo
+	unknown []UnknownElement
+	written []bool
+	// next is the index in info.fields of the next field to write
+	next int
+}
+
+// beginStruct writes the start of v, which must be an addressable struct,
+// and any unknown elements which come first. The elements of parsed
+// extensions are written in their original position along with the unknown
+// elements.
+func (fe *feedEncoder) beginStruct(start xml.StartElement, v reflect.Value) (*structEncoder, error) {
+	se := &structEncoder{fe: fe, v: v, info: getEncodeInfo(v.Type())}
+
+	if se.info.unknown >= 0 {
+		se.unknown = v.Field(se.info.unknown).Interface().([]UnknownElement)
+	}
+	if se.info.extensions >= 0 {
+		for _, ext := range v.Field(se.info.extensions).Interface().([]Extension) {
+			if !ext.anchored {
+				continue
+			}
+			els, err := extensionElements(ext)
+			if err != nil {
+				return nil, err
+			}
+			se.unknown = append(se.unknown[:len(se.unknown):len(se.unknown)], els...)
+		}
+	}
+	se.written = make([]bool, len(se.unknown))
+	if se.info.unknownAttrs >= 0 {
+		for _, a := range v.Field(se.info.unknownAttrs).Interface().([]xml.Attr) {
+			if !isNamespaceDecl(a) {
+				start.Attr = append(start.Attr, xml.Attr{Name: fe.name(a.Name, false), Value: a.Value})
+			}
+		}
+	}
+	se.start = start
+
+	if err := fe.e.EncodeToken(start); err != nil {
+		return nil, err
+	}
+	return se, se.writeUnknownAfter(xml.Name{}, false)
+}
+
+// encodeFieldsUntil writes the fields up to, but not including, the field
+// with the given struct field index, along with the unknown elements which
+// follow them. An index of -1 writes all remaining fields.
+func (se *structEncoder) encodeFieldsUntil(index int) error {
+	for ; se.next < len(se.info.fields); se.next++ {
+		f := se.info.fields[se.next]
+		if f.index == index {
+			return nil
+		}
+		fv := se.v.Field(f.index)
+		if f.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		if err := se.fe.encodeField(f.tagName, fv); err != nil {
+			return err
+		}
+		if err := se.writeUnknownAfter(f.name, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// end writes the remaining fields, unknown elements and extensions, and the
+// end of the struct.
+func (se *structEncoder) end() error {
+	if err := se.encodeFieldsUntil(-1); err != nil {
+		return err
+	}
+	if err := se.writeUnknownAfter(xml.Name{}, true); err != nil {
+		return err
+	}
+	if err := se.fe.encodeExtensions(se.v, se.info, true); err != nil {
+		return err
+	}
+	return se.fe.e.EncodeToken(se.start.End())
+}
+
+// writeUnknownAfter writes the unknown elements positioned after name, or
+// all of those not yet written if all is set.
+func (se *structEncoder) writeUnknownAfter(name xml.Name, all bool) error {
+	for i, el := range se.unknown {
+		if se.written[i] || (!all && el.After != name) {
+			continue
+		}
+		se.written[i] = true
+		if err := se.fe.encodeUnknown(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeFields writes the fields of v, a struct, without a surrounding
+// element.
+func (fe *feedEncoder) encodeFields(v reflect.Value) error {
+	for _, f := range getEncodeInfo(v.Type()).fields {
+		fv := v.Field(f.index)
+		if f.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		if err := fe.encodeField(f.tagName, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeNamespacedFields writes the fields and unknown elements of v, a
+// struct, which are in a namespace other than skipNamespace, along with its
+// extensions. It is used to write the extension elements of a podcast or
+// item in formats other than RSS.
+func (fe *feedEncoder) encodeNamespacedFields(v reflect.Value, skipNamespace string) error {
+	info := getEncodeInfo(v.Type())
+	for _, f := range info.fields {
+		fv := v.Field(f.index)
+		// empty values, even of fields without omitempty, aren't written as
+		// they are only required in RSS
+		if f.name.Space == "" || f.name.Space == skipNamespace || isEmptyValue(fv) || fv.IsZero() {
+			continue
+		}
+		if err := fe.encodeField(f.tagName, fv); err != nil {
+			return err
+		}
+	}
+	if info.unknown >= 0 {
+		for _, el := range v.Field(info.unknown).Interface().([]UnknownElement) {
+			if el.XMLName.Space == "" || el.XMLName.Space == skipNamespace {
+				continue
+			}
+			if err := fe.encodeUnknown(el); err != nil {
+				return err
+			}
+		}
+	}
+	return fe.encodeExtensions(v, info, false)
+}
+
+// encodeExtensions writes the elements of the extensions of v, except those
+// of parsed extensions if skipAnchored is set, which are written by
+// structEncoder in their original position.
+func (fe *feedEncoder) encodeExtensions(v reflect.Value, info *encodeInfo, skipAnchored bool) error {
+	if info.extensions < 0 {
+		return nil
+	}
+	for _, ext := range v.Field(info.extensions).Interface().([]Extension) {
+		if skipAnchored && ext.anchored {
+			continue
+		}
+		els, err := extensionElements(ext)
+		if err != nil {
+			return err
+		}
+		for _, el := range els {
+			if err := fe.encodeUnknown(el); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (fe *feedEncoder) encodeField(tagName string, v reflect.Value) error {
+	start := xml.StartElement{Name: xml.Name{Local: tagName}}
+
+	t := v.Type()
+	switch {
+	case t.Kind() == reflect.Slice && hasUnknownFields(t.Elem()):
+		for i := 0; i < v.Len(); i++ {
+			if err := fe.encodeField(tagName, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case t.Kind() == reflect.Pointer && hasUnknownFields(t):
+		if v.IsNil() {
+			return nil
+		}
+		return fe.encodeStruct(start, v.Elem())
+	case hasUnknownFields(t):
+		return fe.encodeStruct(start, v)
+	}
+
+	if t, ok := iso8601Time(tagName, v); ok {
+		return fe.e.EncodeElement(t.marshalISO8601(), start)
+	}
+
+	if v.CanAddr() {
+		v = v.Addr()
+	}
+	return fe.e.EncodeElement(v.Interface(), start)
+}
+
+// iso8601Prefixes are the prefixes of namespaces which use ISO 8601 dates
+// rather than the RFC 1123 dates used by RSS.
+var iso8601Prefixes = []string{"dc", "sy"}
+
+// iso8601Time returns v if it is a Time, or a non-nil *Time, to be written
+// as the element tagName in a namespace which uses ISO 8601 dates.
+func iso8601Time(tagName string, v reflect.Value) (Time, bool) {
+	prefix, _, ok := strings.Cut(tagName, ":")
+	if !ok || !slices.Contains(iso8601Prefixes, prefix) {
+		return Time{}, false
+	}
+	switch t := v.Interface().(type) {
+	case Time:
+		return t, true
+	case *Time:
+		if t != nil {
+			return *t, true
+		}
+	}
+	return Time{}, false
+}
+
+func (fe *feedEncoder) encodeUnknown(el UnknownElement) error {
+	start := xml.StartElement{Name: fe.name(el.XMLName, true)}
+	for _, a := range el.Attrs {
+		if !isNamespaceDecl(a) {
+			start.Attr = append(start.Attr, xml.Attr{Name: fe.name(a.Name, false), Value: a.Value})
+		}
+	}
+
+	if err := fe.e.EncodeToken(start); err != nil {
+		return err
+	}
+	// ignore whitespace between child elements
+	if el.Text != "" && (len(el.Children) == 0 || strings.TrimSpace(el.Text) != "") {
+		if err := fe.e.EncodeToken(xml.CharData(el.Text)); err != nil {
+			return err
+		}
+	}
+	for _, child := range el.Children {
+		if err := fe.encodeUnknown(child); err != nil {
+			return err
+		}
+	}
+	return fe.e.EncodeToken(start.End())
+}
+
+// name converts a parsed name, which contains the namespace URL, to a
+// prefixed name. If there is no prefix for the namespace, the name is left
+// for encoding/xml to declare the namespace itself.
+func (fe *feedEncoder) name(n xml.Name, isElement bool) xml.Name {
+	if n.Space == "" {
+		return n
+	}
+	if prefix, ok := fe.prefixes[n.Space]; ok {
+		return xml.Name{Local: prefix + ":" + n.Local}
+	}
+	if !isElement && !strings.Contains(n.Space, ":") {
+		// an undeclared prefix, which can't be given a namespace
+		return xml.Name{Local: n.Space + ":" + n.Local}
+	}
+	return n
+}
+
+func isNamespaceDecl(a xml.Attr) bool {
+	return a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns")
+}
+
+type encodeInfo struct {
+	fields       []encodeField
+	unknown      int
+	unknownAttrs int
+	extensions   int
+}
+
+type encodeField struct {
+	index     int
+	tagName   string
+	name      xml.Name
+	omitEmpty bool
+}
+
+var encodeInfoCache sync.Map
+
+var (
+	unknownElementsType = reflect.TypeFor[[]UnknownElement]()
+	xmlAttrsType        = reflect.TypeFor[[]xml.Attr]()
+	extensionsType      = reflect.TypeFor[[]Extension]()
+)
+
+// getEncodeInfo returns the element fields of a struct, along with the index
+// of its unknown element, unknown attribute and extension fields, or -1 if it
+// has none.
+func getEncodeInfo(t reflect.Type) *encodeInfo {
+	if info, ok := encodeInfoCache.Load(t); ok {
+		return info.(*encodeInfo)
+	}
+
+	info := &encodeInfo{unknown: -1, unknownAttrs: -1, extensions: -1}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Name == "XMLName" {
+			continue
+		}
+		tag, hasTag := f.Tag.Lookup("xml")
+		tagName, flags, _ := strings.Cut(tag, ",")
+		switch {
+		case tag == "-" && f.Type == extensionsType:
+			info.extensions = i
+			continue
+		case tag == "-":
+			continue
+		case flags == "any" && f.Type == unknownElementsType:
+			info.unknown = i
+			continue
+		case flags == "any,attr" && f.Type == xmlAttrsType:
+			info.unknownAttrs = i
+			continue
+		case strings.Contains(flags, "attr") || strings.Contains(flags, "chardata") ||
+			strings.Contains(flags, "cdata") || strings.Contains(flags, "innerxml") ||
+			strings.Contains(flags, "comment") || strings.Contains(flags, "any"):
+			continue
+		}
+		if !hasTag || tagName == "" {
+			tagName = f.Name
+		}
+		info.fields = append(info.fields, encodeField{
+			index:     i,
+			tagName:   tagName,
+			name:      tagNameToName(tagName),
+			omitEmpty: strings.Contains(flags, "omitempty"),
+		})
+	}
+
+	encodeInfoCache.Store(t, info)
+	return info
+}
+
+// hasUnknownFields reports whether t, or the type it points to, is a struct
+// with a field for unknown elements.
+func hasUnknownFields(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && getEncodeInfo(t).unknown >= 0
+}
+
+// tagNameToName converts a prefixed name from a struct tag to the name the
+// element has when parsed.
+func tagNameToName(tagName string) xml.Name {
+	if prefix, local, ok := strings.Cut(tagName, ":"); ok {
+		if url, ok := builtinNamespaceURL(prefix); ok {
+			return xml.Name{Space: url, Local: local}
+		}
+	}
+	return xml.Name{Local: tagName}
+}
+
+// isEmptyValue is the same as in encoding/xml, used for omitempty
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package gopodcast
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+)
+
+// Extension is a value holding the elements of a namespace which isn't
+// modelled by this package, see Parser.RegisterExtension.
+type Extension struct {
+	Namespace string
+	Prefix    string
+	Value     any
+
+	// After is the name of the known element which came before the first
+	// element of the extension in the parsed feed, used to write its
+	// elements back out in the same position, as with UnknownElement.After.
+	// Extensions which weren't parsed are written after all other elements.
+	After xml.Name
+
+	// anchored is set for parsed extensions, which are written at After
+	anchored bool
+}
+
+type extensionType struct {
+	namespace string
+	prefix    string
+	typ       reflect.Type
+}
+
+// RegisterExtension registers a namespace whose elements are decoded into a
+// new value of the same type as v, instead of being kept as unknown elements.
+// v is a struct, or a pointer to one, with fields for the elements of the
+// namespace found in the channel or an item, for example:
+//
+//	type HouseTags struct {
+//		Rating  string   `xml:"rating"`
+//		Sponsor []string `xml:"sponsor"`
+//	}
+//
+// Decoded values are returned by Podcast.Extension and Item.Extension as a
+// pointer, e.g. *HouseTags, and are written with the given prefix. An error
+// is returned if v isn't a struct or a pointer to one.
+func (p *Parser) RegisterExtension(namespace, prefix string, v any) error {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return errors.New("extension value is nil")
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported extension type '%s'", reflect.TypeOf(v))
+	}
+	p.extensions = slices.DeleteFunc(p.extensions, func(ext extensionType) bool {
+		return ext.namespace == namespace
+	})
+	p.extensions = append(p.extensions, extensionType{namespace: namespace, prefix: prefix, typ: t})
+	return nil
+}
+
+// Extension returns the value decoded for a registered namespace, or nil if
+// the podcast has no elements in that namespace.
+func (p *Podcast) Extension(namespace string) any {
+	return findExtension(p.Extensions, namespace)
+}
+
+// SetExtension sets the value to write for a namespace, replacing any
+// existing value, which keeps its position. See Parser.RegisterExtension.
+func (p *Podcast) SetExtension(namespace, prefix string, v any) {
+	p.Extensions = setExtension(p.Extensions, namespace, prefix, v)
+}
+
+// Extension returns the value decoded for a registered namespace, or nil if
+// the item has no elements in that namespace.
+func (i *Item) Extension(namespace string) any {
+	return findExtension(i.Extensions, namespace)
+}
+
+// SetExtension sets the value to write for a namespace, replacing any
+// existing value, which keeps its position. See Parser.RegisterExtension.
+func (i *Item) SetExtension(namespace, prefix string, v any) {
+	i.Extensions = setExtension(i.Extensions, namespace, prefix, v)
+}
+
+func findExtension(exts []Extension, namespace string) any {
+	for _, ext := range exts {
+		if ext.Namespace == namespace {
+			return ext.Value
+		}
+	}
+	return nil
+}
+
+func setExtension(exts []Extension, namespace, prefix string, v any) []Extension {
+	ext := Extension{Namespace: namespace, Prefix: prefix, Value: v}
+	if i := slices.IndexFunc(exts, func(ext Extension) bool { return ext.Namespace == namespace }); i >= 0 {
+		ext.After, ext.anchored = exts[i].After, exts[i].anchored
+		exts = slices.Delete(exts, i, i+1)
+	}
+	return append(exts, ext)
+}
+
+// decodeExtensions moves the unknown elements of registered namespaces into
+// extension values.
+func (p *Parser) decodeExtensions(pc *Podcast) error {
+	for _, ext := range p.extensions {
+		var err error
+		pc.UnknownElements, pc.Extensions, err = ext.decode(pc.UnknownElements, pc.Extensions)
+		if err != nil {
+			return err
+		}
+		for _, item := range pc.Items {
+			item.UnknownElements, item.Extensions, err = ext.decode(item.UnknownElements, item.Extensions)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (ext extensionType) decode(unknown []UnknownElement, exts []Extension) ([]UnknownElement, []Extension, error) {
+	var matched []UnknownElement
+	unknown = slices.DeleteFunc(unknown, func(el UnknownElement) bool {
+		if el.XMLName.Space == ext.namespace {
+			matched = append(matched, el)
+			return true
+		}
+		return false
+	})
+	if len(matched) == 0 {
+		return unknown, exts, nil
+	}
+
+	v := reflect.New(ext.typ).Interface()
+	tr := &unknownTokenReader{}
+	tr.push(xml.EndElement{Name: xml.Name{Local: "extension"}})
+	for i := len(matched) - 1; i >= 0; i-- {
+		tr.pushElement(matched[i])
+	}
+	tr.push(xml.StartElement{Name: xml.Name{Local: "extension"}})
+	if err := xml.NewTokenDecoder(tr).Decode(v); err != nil {
+		return nil, nil, err
+	}
+
+	exts = setExtension(exts, ext.namespace, ext.prefix, v)
+	exts[len(exts)-1].After = matched[0].After
+	exts[len(exts)-1].anchored = true
+	return unknown, exts, nil
+}
+
+// unknownTokenReader is an xml.TokenReader over a stack of tokens.
+type unknownTokenReader struct {
+	tokens []xml.Token
+}
+
+func (r *unknownTokenReader) push(tok xml.Token) {
+	r.tokens = append(r.tokens, tok)
+}
+
+// pushElement pushes the tokens of el in reverse, so they are read in order.
+func (r *unknownTokenReader) pushElement(el UnknownElement) {
+	start := xml.StartElement{Name: el.XMLName, Attr: el.Attrs}
+	r.push(start.End())
+	for i := len(el.Children) - 1; i >= 0; i-- {
+		r.pushElement(el.Children[i])
+	}
+	if el.Text != "" {
+		r.push(xml.CharData(el.Text))
+	}
+	r.push(start)
+}
+
+func (r *unknownTokenReader) Token() (xml.Token, error) {
+	if len(r.tokens) == 0 {
+		return nil, io.EOF
+	}
+	tok := r.tokens[len(r.tokens)-1]
+	r.tokens = r.tokens[:len(r.tokens)-1]
+	return tok, nil
+}
+
+// extensionElements converts an extension value to the elements to write,
+// positioned at the extension's After if it is anchored.
+func extensionElements(ext Extension) ([]UnknownElement, error) {
+	b, err := xml.Marshal(ext.Value)
+	if err != nil {
+		return nil, err
+	}
+	var wrapper struct {
+		Children []UnknownElement `xml:",any"`
+	}
+	if err := xml.NewDecoder(bytes.NewReader(b)).Decode(&wrapper); err != nil {
+		return nil, err
+	}
+	setDefaultNamespace(wrapper.Children, ext.Namespace)
+	if ext.anchored {
+		for i := range wrapper.Children {
+			wrapper.Children[i].After = ext.After
+		}
+	}
+	return wrapper.Children, nil
+}
+
+func setDefaultNamespace(els []UnknownElement, namespace string) {
+	for i := range els {
+		if els[i].XMLName.Space == "" {
+			els[i].XMLName.Space = namespace
+		}
+		setDefaultNamespace(els[i].Children, namespace)
+	}
+}
@@ -0,0 +1,146 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+const houseNS = "https://www.example.com/house"
+
+type houseTags struct {
+	Rating  string   `xml:"rating"`
+	Sponsor []string `xml:"sponsor"`
+}
+
+const houseFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss xmlns:house="https://www.example.com/house" version="2.0">
+  <channel>
+    <title>Test podcast 1</title>
+    <house:rating>PG</house:rating>
+    <item>
+      <title>Test episode 1</title>
+      <house:sponsor>Sponsor 1</house:sponsor>
+      <house:sponsor>Sponsor 2</house:sponsor>
+      <other>Not an extension</other>
+    </item>
+    <item>
+      <title>Test episode 2</title>
+    </item>
+  </channel>
+</rss>`
+
+func TestParseFeed_Extension(t *testing.T) {
+	parser := gopodcast.NewParser()
+	if err := parser.RegisterExtension(houseNS, "house", houseTags{}); err != nil {
+		t.Fatal(err)
+	}
+
+	podcast, err := parser.ParseFeed(strings.NewReader(houseFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channelTags := podcast.Extension(houseNS).(*houseTags)
+	assertStr(t, "PG", channelTags.Rating)
+	assertInt(t, 0, len(podcast.UnknownElements))
+
+	itemTags := podcast.Items[0].Extension(houseNS).(*houseTags)
+	assertStr(t, "Sponsor 1,Sponsor 2", strings.Join(itemTags.Sponsor, ","))
+	assertInt(t, 1, len(podcast.Items[0].UnknownElements))
+	assertStr(t, "other", podcast.Items[0].UnknownElements[0].XMLName.Local)
+
+	assertTrue(t, podcast.Items[1].Extension(houseNS) == nil)
+}
+
+func TestParseFeed_ExtensionNotRegistered(t *testing.T) {
+	parser := gopodcast.NewParser()
+
+	podcast, err := parser.ParseFeed(strings.NewReader(houseFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertTrue(t, podcast.Extension(houseNS) == nil)
+	assertInt(t, 1, len(podcast.UnknownElements))
+	assertStr(t, houseNS, podcast.UnknownElements[0].XMLName.Space)
+}
+
+func TestWriteFeed_Extension(t *testing.T) {
+	podcast := &gopodcast.Podcast{
+		Title: "Test title",
+		Items: []*gopodcast.Item{
+			{Title: "A podcast 1"},
+		},
+	}
+	podcast.Items[0].SetExtension(houseNS, "house", &houseTags{
+		Rating:  "U",
+		Sponsor: []string{"Sponsor 1"},
+	})
+
+	buf := &bytes.Buffer{}
+	err := podcast.WriteFeedXML(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	assertTrue(t, strings.Contains(out, `xmlns:house="https://www.example.com/house"`))
+	assertTrue(t, strings.Contains(out, `<house:rating>U</house:rating><house:sponsor>Sponsor 1</house:sponsor></item>`))
+
+	// and can be parsed again
+	parser := gopodcast.NewParser()
+	if err := parser.RegisterExtension(houseNS, "house", &houseTags{}); err != nil {
+		t.Fatal(err)
+	}
+	written, err := parser.ParseFeed(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, "U", written.Items[0].Extension(houseNS).(*houseTags).Rating)
+}
+
+func TestWriteFeed_ParsedExtensionPosition(t *testing.T) {
+	parser := gopodcast.NewParser()
+	if err := parser.RegisterExtension(houseNS, "house", houseTags{}); err != nil {
+		t.Fatal(err)
+	}
+
+	podcast, err := parser.ParseFeed(strings.NewReader(houseFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a replaced value keeps its position
+	podcast.SetExtension(houseNS, "house", &houseTags{Rating: "U"})
+
+	buf := &bytes.Buffer{}
+	if err := podcast.WriteFeedXML(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	assertTrue(t, strings.Contains(out, `<title>Test podcast 1</title><house:rating>U</house:rating>`))
+	// rather than at the end of the item
+	assertTrue(t, strings.Contains(out, `<house:sponsor>Sponsor 1</house:sponsor><house:sponsor>Sponsor 2</house:sponsor><enclosure`))
+}
+
+func TestRegisterExtension_InvalidValue(t *testing.T) {
+	parser := gopodcast.NewParser()
+
+	err := parser.RegisterExtension(houseNS, "house", nil)
+	assertNotNil(t, err)
+	assertStr(t, "extension value is nil", err.Error())
+
+	err = parser.RegisterExtension(houseNS, "house", "rating")
+	assertNotNil(t, err)
+	assertStr(t, "unsupported extension type 'string'", err.Error())
+
+	// invalid values aren't registered
+	podcast, err := parser.ParseFeed(strings.NewReader(houseFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTrue(t, podcast.Extension(houseNS) == nil)
+}
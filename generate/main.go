@@ -26,15 +26,22 @@ func main() {
 	}
 }
 
+// keep in sync with namespaceURLs in encode.go
 var nsToURL = map[string]string{
-	"atom":    "http://www.w3.org/2005/Atom",
-	"itunes":  "http://www.itunes.com/dtds/podcast-1.0.dtd",
-	"podcast": "https://podcastindex.org/namespace/1.0",
-	"content": "http://purl.org/rss/1.0/modules/content/",
+	"atom":       "http://www.w3.org/2005/Atom",
+	"itunes":     "http://www.itunes.com/dtds/podcast-1.0.dtd",
+	"podcast":    "https://podcastindex.org/namespace/1.0",
+	"content":    "http://purl.org/rss/1.0/modules/content/",
+	"googleplay": "http://www.google.com/schemas/play-podcasts/1.0",
+	"media":      "http://search.yahoo.com/mrss/",
+	"dc":         "http://purl.org/dc/elements/1.1/",
+	"sy":         "http://purl.org/rss/1.0/modules/syndication/",
+	"spotify":    "http://www.spotify.com/ns/rss",
+	"psc":        "http://podlove.org/simple-chapters",
 }
 
 // types we don't want to transform
-var ignoreTypes = []string{"string", "bool", "int", "int64", "byte", "xml.Name", "Bool", "Time", "YesNo"}
+var ignoreTypes = []string{"string", "bool", "int", "int64", "float64", "byte", "xml.Name", "xml.Attr", "Bool", "Int", "Date", "Explicit", "Time", "NormalPlayTime", "YesNo", "Keywords", "CountryCodes", "UnknownElement", "Namespace", "Extension", "PSCChapter"}
 
 type strct struct {
 	name   string
@@ -42,9 +49,10 @@ type strct struct {
 }
 
 type field struct {
-	name  string
-	fType string
-	tag   string
+	name       string
+	fType      string
+	tag        string
+	namespaced bool
 }
 
 // parses all the structs from a source file along with their fields
@@ -80,9 +88,10 @@ func parseFileStructs(fileName string) ([]strct, error) {
 				}
 				typeStr := string(fsrc[f.Type.Pos()-1 : f.Type.End()-1])
 				strct.fields = append(strct.fields, field{
-					name:  f.Names[0].Name,
-					fType: typeStr,
-					tag:   tag,
+					name:       f.Names[0].Name,
+					fType:      typeStr,
+					tag:        tag,
+					namespaced: f.Tag != nil && tag != f.Tag.Value && !strings.Contains(tag, ",attr"),
 				})
 			}
 			structs = append(structs, strct)
@@ -102,8 +111,28 @@ func generateFile(fileName string, structs []strct) error {
 	fmt.Fprint(output, "import \"encoding/xml\"\n\n")
 
 	for _, strct := range structs {
+		// structs with their own XML methods are parsed as they are
+		if isIgnoreType(strct.name) {
+			continue
+		}
+
+		// fields without a namespace match elements in any namespace, so
+		// namespaced fields go first to take precedence, e.g. so that
+		// itunes:title isn't parsed as title.
+		fields := slices.Clone(strct.fields)
+		slices.SortStableFunc(fields, func(a, b field) int {
+			switch {
+			case a.namespaced && !b.namespaced:
+				return -1
+			case !a.namespaced && b.namespaced:
+				return 1
+			default:
+				return 0
+			}
+		})
+
 		fmt.Fprintf(output, "type xmlFix%s struct {\n", strct.name)
-		for _, field := range strct.fields {
+		for _, field := range fields {
 			fmt.Fprintf(output, "\t%s %s %s\n", field.name, transformFieldType(field.fType), field.tag)
 		}
 		fmt.Fprint(output, "}\n\n")
@@ -155,7 +184,7 @@ func generateFile(fileName string, structs []strct) error {
 }
 
 func transformXMLTag(s string) string {
-	r := regexp.MustCompile(`xml:"(([a-zA-Z]+):([a-zA-Z]+))[,"].*`)
+	r := regexp.MustCompile(`xml:"(([a-zA-Z]+):([a-zA-Z-]+))[,"].*`)
 	matches := r.FindStringSubmatch(s)
 	if len(matches) != 4 {
 		return s
@@ -0,0 +1,44 @@
+package gopodcast
+
+// applyGooglePlayFallback fills in iTunes values which are missing from the
+// podcast and its items with their googleplay equivalents. The channel
+// itunes:explicit is required, so it is always present and never replaced.
+func applyGooglePlayFallback(pc *Podcast) {
+	if pc.ITunesAuthor == "" {
+		pc.ITunesAuthor = pc.GooglePlayAuthor
+	}
+	if pc.ITunesSummary == "" {
+		pc.ITunesSummary = pc.GooglePlayDescription
+	}
+	if pc.ITunesImage.Href == "" && pc.GooglePlayImage != nil {
+		pc.ITunesImage.Href = pc.GooglePlayImage.Href
+	}
+	if len(pc.ITunesCategory) == 0 {
+		for _, c := range pc.GooglePlayCategory {
+			pc.ITunesCategory = append(pc.ITunesCategory, ITunesCategory{Text: c.Text})
+		}
+	}
+	if pc.ITunesOwner == nil && (pc.GooglePlayOwner != "" || pc.GooglePlayEmail != "") {
+		email := pc.GooglePlayOwner
+		if email == "" {
+			email = pc.GooglePlayEmail
+		}
+		pc.ITunesOwner = &ITunesOwner{Name: pc.GooglePlayAuthor, Email: email}
+	}
+
+	for _, item := range pc.Items {
+		if item.ITunesAuthor == "" {
+			item.ITunesAuthor = item.GooglePlayAuthor
+		}
+		if item.ITunesImage == nil && item.GooglePlayImage != nil {
+			item.ITunesImage = &ITunesImage{Href: item.GooglePlayImage.Href}
+		}
+		if item.ITunesExplicit == nil && item.GooglePlayExplicit != "" {
+			explicit := Bool(item.GooglePlayExplicit.IsExplicit())
+			item.ITunesExplicit = &explicit
+		}
+		if item.ITunesBlock == nil {
+			item.ITunesBlock = item.GooglePlayBlock
+		}
+	}
+}
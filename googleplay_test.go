@@ -0,0 +1,146 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+// uses a variation of the namespace URL seen in real feeds
+const googlePlayFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss xmlns:googleplay="http://www.google.com/schemas/play-podcasts/1.0/" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" version="2.0">
+  <channel>
+    <title>Test podcast 1</title>
+    <itunes:explicit>false</itunes:explicit>
+    <googleplay:author>Test author</googleplay:author>
+    <googleplay:description>Test description</googleplay:description>
+    <googleplay:image href="https://www.example.com/image.jpg"/>
+    <googleplay:category text="Comedy"/>
+    <googleplay:explicit>yes</googleplay:explicit>
+    <googleplay:block>yes</googleplay:block>
+    <googleplay:owner>owner@example.com</googleplay:owner>
+    <googleplay:email>email@example.com</googleplay:email>
+    <item>
+      <title>Test episode 1</title>
+      <itunes:author>iTunes author</itunes:author>
+      <googleplay:author>Episode author</googleplay:author>
+      <googleplay:description>Episode description</googleplay:description>
+      <googleplay:image href="https://www.example.com/episode.jpg"/>
+      <googleplay:explicit>no</googleplay:explicit>
+      <googleplay:block>no</googleplay:block>
+    </item>
+  </channel>
+</rss>`
+
+func TestParseFeed_GooglePlay(t *testing.T) {
+	podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(googlePlayFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "Test author", podcast.GooglePlayAuthor)
+	assertStr(t, "Test description", podcast.GooglePlayDescription)
+	assertStr(t, "https://www.example.com/image.jpg", podcast.GooglePlayImage.Href)
+	assertInt(t, 1, len(podcast.GooglePlayCategory))
+	assertStr(t, "Comedy", podcast.GooglePlayCategory[0].Text)
+	assertStr(t, "yes", string(podcast.GooglePlayExplicit))
+	assertBool(t, true, podcast.GooglePlayExplicit.IsExplicit())
+	assertBool(t, true, bool(*podcast.GooglePlayBlock))
+	assertStr(t, "owner@example.com", podcast.GooglePlayOwner)
+	assertStr(t, "email@example.com", podcast.GooglePlayEmail)
+	assertInt(t, 0, len(podcast.UnknownElements))
+
+	item := podcast.Items[0]
+	assertStr(t, "Episode author", item.GooglePlayAuthor)
+	assertStr(t, "Episode description", item.GooglePlayDescription)
+	assertStr(t, "https://www.example.com/episode.jpg", item.GooglePlayImage.Href)
+	assertStr(t, "no", string(item.GooglePlayExplicit))
+	assertBool(t, false, item.GooglePlayExplicit.IsExplicit())
+	assertBool(t, false, bool(*item.GooglePlayBlock))
+	assertInt(t, 0, len(item.UnknownElements))
+
+	// iTunes values are not filled in unless requested
+	assertStr(t, "", podcast.ITunesAuthor)
+	assertNil(t, item.ITunesImage)
+}
+
+func TestParseFeed_GooglePlayFallback(t *testing.T) {
+	parser := gopodcast.NewParser()
+	parser.GooglePlayFallback = true
+
+	podcast, err := parser.ParseFeed(strings.NewReader(googlePlayFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "Test author", podcast.ITunesAuthor)
+	assertStr(t, "Test description", podcast.ITunesSummary)
+	assertStr(t, "https://www.example.com/image.jpg", podcast.ITunesImage.Href)
+	assertInt(t, 1, len(podcast.ITunesCategory))
+	assertStr(t, "Comedy", podcast.ITunesCategory[0].Text)
+	assertStr(t, "Test author", podcast.ITunesOwner.Name)
+	assertStr(t, "owner@example.com", podcast.ITunesOwner.Email)
+	// required, so never replaced
+	assertBool(t, false, bool(podcast.ITunesExplicit))
+
+	item := podcast.Items[0]
+	// present iTunes values are kept
+	assertStr(t, "iTunes author", item.ITunesAuthor)
+	assertStr(t, "https://www.example.com/episode.jpg", item.ITunesImage.Href)
+	assertBool(t, false, bool(*item.ITunesExplicit))
+	assertBool(t, false, bool(*item.ITunesBlock))
+}
+
+func TestWriteFeed_GooglePlay(t *testing.T) {
+	podcast := &gopodcast.Podcast{
+		Title:            "Test title",
+		GooglePlayAuthor: "Test author",
+		GooglePlayImage:  &gopodcast.GooglePlayImage{Href: "https://www.example.com/image.jpg"},
+	}
+
+	buf := &bytes.Buffer{}
+	err := podcast.WriteFeedXML(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	assertTrue(t, strings.Contains(out, `xmlns:googleplay="http://www.google.com/schemas/play-podcasts/1.0"`))
+	assertTrue(t, strings.Contains(out, `<googleplay:author>Test author</googleplay:author>`))
+	assertTrue(t, strings.Contains(out, `<googleplay:image href="https://www.example.com/image.jpg"></googleplay:image>`))
+}
+
+func TestWriteFeed_GooglePlayExplicitRoundTrip(t *testing.T) {
+	for _, explicit := range []string{"yes", "no", "clean"} {
+		t.Run(explicit, func(t *testing.T) {
+			feed := strings.Replace(googlePlayFeed, "<googleplay:explicit>yes</googleplay:explicit>",
+				"<googleplay:explicit>"+explicit+"</googleplay:explicit>", 1)
+			podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(feed))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertStr(t, explicit, string(podcast.GooglePlayExplicit))
+			assertBool(t, explicit == "yes", podcast.GooglePlayExplicit.IsExplicit())
+
+			buf := &bytes.Buffer{}
+			if err := podcast.WriteFeedXML(buf); err != nil {
+				t.Fatal(err)
+			}
+			assertTrue(t, strings.Contains(buf.String(), "<googleplay:explicit>"+explicit+"</googleplay:explicit>"))
+		})
+	}
+}
+
+func TestWriteFeed_GooglePlayNamespaceOnlyWhenUsed(t *testing.T) {
+	podcast := &gopodcast.Podcast{Title: "Test title"}
+
+	buf := &bytes.Buffer{}
+	err := podcast.WriteFeedXML(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertTrue(t, !strings.Contains(buf.String(), "googleplay"))
+}
@@ -3,8 +3,14 @@
 package gopodcast
 
 import (
+	"cmp"
 	"encoding/xml"
 	"io"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 )
 
 type feed struct {
@@ -27,112 +33,511 @@ var emptyFeed = &feed{
 
 type Podcast struct {
 	// PSP Required
-	AtomLink       AtomLink         `xml:"atom:link"`
-	Title          string           `xml:"title"`
-	Description    Description      `xml:"description"`
-	Link           string           `xml:"link"`
-	Language       string           `xml:"language"`
-	ITunesCategory []ITunesCategory `xml:"itunes:category"`
-	ITunesExplicit Bool             `xml:"itunes:explicit"`
-	ITunesImage    ITunesImage      `xml:"itunes:image"`
+	AtomLink       AtomLink         `xml:"atom:link" json:"atomLink,omitempty"`
+	Title          string           `xml:"title" json:"title,omitempty"`
+	Description    Description      `xml:"description" json:"description,omitempty"`
+	Link           string           `xml:"link" json:"link,omitempty"`
+	Language       string           `xml:"language" json:"language,omitempty"`
+	ITunesCategory []ITunesCategory `xml:"itunes:category" json:"itunesCategory,omitempty"`
+	ITunesExplicit Bool             `xml:"itunes:explicit" json:"itunesExplicit,omitempty"`
+	ITunesImage    ITunesImage      `xml:"itunes:image" json:"itunesImage,omitempty"`
 
 	// PSP Recommended
-	PodcastLocked *YesNo `xml:"podcast:locked,omitempty"`
-	PodcastGUID   string `xml:"podcast:guid,omitempty"`
-	ITunesAuthor  string `xml:"itunes:author,omitempty"`
+	PodcastLocked *YesNo `xml:"podcast:locked,omitempty" json:"podcastLocked,omitempty"`
+	PodcastGUID   string `xml:"podcast:guid,omitempty" json:"podcastGUID,omitempty"`
+	ITunesAuthor  string `xml:"itunes:author,omitempty" json:"itunesAuthor,omitempty"`
 
 	// PSP Optional
-	Copyright      string          `xml:"copyright,omitempty"`
-	PodcastText    *PodcastText    `xml:"podcast:txt,omitempty"`
-	PodcastFunding *PodcastFunding `xml:"podcast:funding,omitempty"`
-	ITunesType     string          `xml:"itunes:type,omitempty"`
-	ITunesComplete *YesNo          `xml:"itunes:complete,omitempty"`
+	Copyright       string           `xml:"copyright,omitempty" json:"copyright,omitempty"`
+	PodcastText     *PodcastText     `xml:"podcast:txt,omitempty" json:"podcastText,omitempty"`
+	PodcastFundings []PodcastFunding `xml:"podcast:funding,omitempty" json:"podcastFundings,omitempty"`
+	ITunesType      string           `xml:"itunes:type,omitempty" json:"itunesType,omitempty"`
+	ITunesComplete  *YesNo           `xml:"itunes:complete,omitempty" json:"itunesComplete,omitempty"`
 
 	// Other fields
+	ITunesOwner    *ITunesOwner `xml:"itunes:owner,omitempty" json:"itunesOwner,omitempty"`
+	ITunesSummary  string       `xml:"itunes:summary,omitempty" json:"itunesSummary,omitempty"`
+	ITunesSubtitle string       `xml:"itunes:subtitle,omitempty" json:"itunesSubtitle,omitempty"`
+
+	// ITunesNewFeedURL is set when a podcast has moved to a new feed URL.
+	// Parser.FollowNewFeedURL can be used to follow it automatically.
+	ITunesNewFeedURL string `xml:"itunes:new-feed-url,omitempty" json:"itunesNewFeedURL,omitempty"`
+
+	ITunesKeywords            Keywords `xml:"itunes:keywords,omitempty" json:"itunesKeywords,omitempty"`
+	ITunesApplePodcastsVerify string   `xml:"itunes:applepodcastsverify,omitempty" json:"itunesApplePodcastsVerify,omitempty"`
 	// TODO other podcast index namespace fields
 	// TODO other itunes fields
 
-	Items []*Item `xml:"item"`
+	// Google Play fields, see Parser.GooglePlayFallback
+	GooglePlayAuthor      string               `xml:"googleplay:author,omitempty" json:"googlePlayAuthor,omitempty"`
+	GooglePlayDescription string               `xml:"googleplay:description,omitempty" json:"googlePlayDescription,omitempty"`
+	GooglePlayImage       *GooglePlayImage     `xml:"googleplay:image,omitempty" json:"googlePlayImage,omitempty"`
+	GooglePlayCategory    []GooglePlayCategory `xml:"googleplay:category,omitempty" json:"googlePlayCategory,omitempty"`
+	GooglePlayExplicit    Explicit             `xml:"googleplay:explicit,omitempty" json:"googlePlayExplicit,omitempty"`
+	GooglePlayBlock       *YesNo               `xml:"googleplay:block,omitempty" json:"googlePlayBlock,omitempty"`
+	GooglePlayOwner       string               `xml:"googleplay:owner,omitempty" json:"googlePlayOwner,omitempty"`
+	GooglePlayEmail       string               `xml:"googleplay:email,omitempty" json:"googlePlayEmail,omitempty"`
+
+	// Media RSS fields
+	MediaThumbnails []MediaThumbnail `xml:"media:thumbnail,omitempty" json:"mediaThumbnails,omitempty"`
+	MediaRatings    []MediaRating    `xml:"media:rating,omitempty" json:"mediaRatings,omitempty"`
+
+	// Spotify fields
+	SpotifyLimit           *SpotifyLimit `xml:"spotify:limit,omitempty" json:"spotifyLimit,omitempty"`
+	SpotifyCountryOfOrigin CountryCodes  `xml:"spotify:countryOfOrigin,omitempty" json:"spotifyCountryOfOrigin,omitempty"`
+
+	// Dublin Core and syndication fields. Dates have the same type as
+	// PubDate, but are in ISO 8601 format rather than the RFC 1123 format
+	// used by RSS, see NewISO8601Date.
+	DCCreator         string `xml:"dc:creator,omitempty" json:"dcCreator,omitempty"`
+	DCDate            Date   `xml:"dc:date,omitempty" json:"dcDate,omitempty"`
+	DCLanguage        string `xml:"dc:language,omitempty" json:"dcLanguage,omitempty"`
+	SyUpdatePeriod    string `xml:"sy:updatePeriod,omitempty" json:"syUpdatePeriod,omitempty"`
+	SyUpdateFrequency *int   `xml:"sy:updateFrequency,omitempty" json:"syUpdateFrequency,omitempty"`
+	SyUpdateBase      Date   `xml:"sy:updateBase,omitempty" json:"syUpdateBase,omitempty"`
+
+	// RSS 2.0 fields
+	PubDate        Date       `xml:"pubDate,omitempty" json:"pubDate,omitempty"`
+	LastBuildDate  Date       `xml:"lastBuildDate,omitempty" json:"lastBuildDate,omitempty"`
+	TTL            Int        `xml:"ttl,omitempty" json:"ttl,omitempty"`
+	Generator      string     `xml:"generator,omitempty" json:"generator,omitempty"`
+	ManagingEditor string     `xml:"managingEditor,omitempty" json:"managingEditor,omitempty"`
+	WebMaster      string     `xml:"webMaster,omitempty" json:"webMaster,omitempty"`
+	Docs           string     `xml:"docs,omitempty" json:"docs,omitempty"`
+	Image          *Image     `xml:"image,omitempty" json:"image,omitempty"`
+	Category       []Category `xml:"category,omitempty" json:"category,omitempty"`
+	SkipHours      *SkipHours `xml:"skipHours,omitempty" json:"skipHours,omitempty"`
+	SkipDays       *SkipDays  `xml:"skipDays,omitempty" json:"skipDays,omitempty"`
+
+	Items []*Item `xml:"item" json:"items,omitempty"`
+
+	// UnknownElements and UnknownAttrs hold any elements and attributes
+	// which aren't modelled above, so that they are kept when a parsed feed
+	// is written. Namespaces holds the namespaces declared in the feed, which
+	// are used to write unknown elements with their original prefixes.
+	UnknownElements []UnknownElement `xml:",any" json:"unknownElements,omitempty"`
+	UnknownAttrs    []xml.Attr       `xml:",any,attr" json:"unknownAttrs,omitempty"`
+	Namespaces      []Namespace      `xml:"-" json:"namespaces,omitempty"`
+
+	// Extensions holds values for namespaces registered with
+	// Parser.RegisterExtension, see Podcast.Extension.
+	Extensions []Extension `xml:"-" json:"-"`
+
+	// Deprecated: use PodcastFundings, which supports more than one funding
+	// link. When parsing, this is set to the first of PodcastFundings. When
+	// writing, it is written before PodcastFundings unless already present.
+	PodcastFunding *PodcastFunding `xml:"-" json:"-"`
 }
 
 func (p *Podcast) WriteFeedXML(w io.Writer) error {
-	feed := emptyFeed
-	feed.Channel = p
 	_, err := w.Write([]byte(xml.Header))
 	if err != nil {
 		return err
 	}
-	return xml.NewEncoder(w).Encode(feed)
+
+	namespaces := p.writeNamespaces()
+	e := xml.NewEncoder(w)
+	fe := newFeedEncoder(e, namespaces)
+
+	start := rssStart(namespaces)
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	channel := reflect.ValueOf(p.withLegacyFields()).Elem()
+	if err := fe.encodeStruct(xml.StartElement{Name: xml.Name{Local: "channel"}}, channel); err != nil {
+		return err
+	}
+
+	if err := e.EncodeToken(start.End()); err != nil {
+		return err
+	}
+	return e.Flush()
+}
+
+// rssStart returns the start of the rss element, declaring the given
+// namespaces.
+func rssStart(namespaces []Namespace) xml.StartElement {
+	start := xml.StartElement{
+		Name: xml.Name{Local: "rss"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "version"}, Value: emptyFeed.Version},
+			{Name: xml.Name{Local: "xmlns:content"}, Value: emptyFeed.XMLNSContent},
+			{Name: xml.Name{Local: "xmlns:podcast"}, Value: emptyFeed.XMLNSPodcast},
+			{Name: xml.Name{Local: "xmlns:atom"}, Value: emptyFeed.XMLNSAtom},
+			{Name: xml.Name{Local: "xmlns:itunes"}, Value: emptyFeed.XMLNSITunes},
+		},
+	}
+	for _, ns := range namespaces[numFixedNamespaces:] {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:" + ns.Prefix}, Value: ns.URI})
+	}
+	return start
+}
+
+// withLegacyFields returns a copy of the podcast with the values of any
+// deprecated fields merged into the fields which replace them.
+func (p *Podcast) withLegacyFields() *Podcast {
+	if p.PodcastFunding == nil || slices.Contains(p.PodcastFundings, *p.PodcastFunding) {
+		return p
+	}
+	pc := *p
+	pc.PodcastFundings = append([]PodcastFunding{*p.PodcastFunding}, p.PodcastFundings...)
+	return &pc
+}
+
+// ItemsByITunesOrder returns the podcast's items sorted by itunes:order.
+// Items without a valid order come after those with one, in their original
+// order.
+func (p *Podcast) ItemsByITunesOrder() []*Item {
+	items := slices.Clone(p.Items)
+	slices.SortStableFunc(items, func(a, b *Item) int {
+		aOrder, aOK := a.ITunesOrder.Value()
+		bOrder, bOK := b.ITunesOrder.Value()
+		switch {
+		case !aOK && !bOK:
+			return 0
+		case !aOK:
+			return 1
+		case !bOK:
+			return -1
+		default:
+			return cmp.Compare(aOrder, bOrder)
+		}
+	})
+	return items
 }
 
 type AtomLink struct {
-	Href string `xml:"href,attr"`
-	Rel  string `xml:"rel,attr"`
-	Type string `xml:"type,attr"`
+	Href string `xml:"href,attr" json:"href,omitempty"`
+	Rel  string `xml:"rel,attr" json:"rel,omitempty"`
+	Type string `xml:"type,attr" json:"type,omitempty"`
 }
 
 type Description struct {
-	Text string `xml:",cdata"`
+	Text string `xml:",cdata" json:"text,omitempty"`
 }
 
 type ITunesCategory struct {
-	Text        string          `xml:"text,attr"`
-	SubCategory *ITunesCategory `xml:"itunes:category,omitempty"`
+	Text        string          `xml:"text,attr" json:"text,omitempty"`
+	SubCategory *ITunesCategory `xml:"itunes:category,omitempty" json:"subCategory,omitempty"`
 }
 
 type ITunesImage struct {
-	Href string `xml:"href,attr"`
+	Href string `xml:"href,attr" json:"href,omitempty"`
+}
+
+type ITunesOwner struct {
+	Name  string `xml:"itunes:name" json:"name,omitempty"`
+	Email string `xml:"itunes:email" json:"email,omitempty"`
+}
+
+type GooglePlayImage struct {
+	Href string `xml:"href,attr" json:"href,omitempty"`
+}
+
+type GooglePlayCategory struct {
+	Text string `xml:"text,attr" json:"text,omitempty"`
+}
+
+// SpotifyLimit limits the number of episodes shown on Spotify to the most
+// recent RecentCount.
+type SpotifyLimit struct {
+	RecentCount int `xml:"recentCount,attr" json:"recentCount,omitempty"`
 }
 
 type PodcastText struct {
-	Purpose string `xml:"purpose,attr,omitempty"`
-	Text    string `xml:",chardata"`
+	Purpose string `xml:"purpose,attr,omitempty" json:"purpose,omitempty"`
+	Text    string `xml:",chardata" json:"text,omitempty"`
 }
 
 type PodcastFunding struct {
-	URL  string `xml:"url,attr"`
-	Text string `xml:",chardata"`
+	URL  string `xml:"url,attr" json:"url,omitempty"`
+	Text string `xml:",chardata" json:"text,omitempty"`
+}
+
+type Image struct {
+	URL    string `xml:"url" json:"url,omitempty"`
+	Title  string `xml:"title" json:"title,omitempty"`
+	Link   string `xml:"link" json:"link,omitempty"`
+	Width  Int    `xml:"width,omitempty" json:"width,omitempty"`
+	Height Int    `xml:"height,omitempty" json:"height,omitempty"`
+}
+
+type Category struct {
+	Domain string `xml:"domain,attr,omitempty" json:"domain,omitempty"`
+	Text   string `xml:",chardata" json:"text,omitempty"`
+}
+
+// SkipHours lists hours, from 0 to 23 in GMT, during which the feed should
+// not be refreshed.
+type SkipHours struct {
+	Hours []Int `xml:"hour" json:"hours,omitempty"`
+}
+
+// SkipDays lists days, e.g. "Saturday", during which the feed should not be
+// refreshed.
+type SkipDays struct {
+	Days []string `xml:"day" json:"days,omitempty"`
 }
 
 type Item struct {
 	// PSP required
-	Title     string    `xml:"title"`
-	Enclosure Enclosure `xml:"enclosure"`
-	GUID      ItemGUID  `xml:"guid"`
+	Title     string    `xml:"title" json:"title,omitempty"`
+	Enclosure Enclosure `xml:"enclosure" json:"enclosure,omitempty"`
+	GUID      ItemGUID  `xml:"guid" json:"guid,omitempty"`
 
 	// PSP Recommended
-	Link              string              `xml:"link,omitempty"`
-	PubDate           *Time               `xml:"pubDate,omitempty"`
-	Description       *Description        `xml:"description,omitempty"`
-	ITunesDuration    string              `xml:"itunes:duration,omitempty"`
-	ITunesImage       *ITunesImage        `xml:"itunes:image,omitempty"`
-	ITunesExplicit    *Bool               `xml:"itunes:explicit,omitempty"`
-	PodcastTranscript []PodcastTranscript `xml:"podcast:transcript,omitempty"`
+	Link              string              `xml:"link,omitempty" json:"link,omitempty"`
+	PubDate           *Time               `xml:"pubDate,omitempty" json:"pubDate,omitempty"`
+	Description       *Description        `xml:"description,omitempty" json:"description,omitempty"`
+	ITunesDuration    string              `xml:"itunes:duration,omitempty" json:"itunesDuration,omitempty"`
+	ITunesImage       *ITunesImage        `xml:"itunes:image,omitempty" json:"itunesImage,omitempty"`
+	ITunesExplicit    *Bool               `xml:"itunes:explicit,omitempty" json:"itunesExplicit,omitempty"`
+	PodcastTranscript []PodcastTranscript `xml:"podcast:transcript,omitempty" json:"podcastTranscript,omitempty"`
 
 	// PSP Optional
-	ITunesEpisode     string `xml:"itunes:episode,omitempty"`
-	ITunesSeason      string `xml:"itunes:season,omitempty"`
-	ITunesEpisodeType string `xml:"itunes:episodeType,omitempty"`
-	ITunesBlock       *YesNo `xml:"itunes:block,omitempty"`
+	ITunesEpisode     string `xml:"itunes:episode,omitempty" json:"itunesEpisode,omitempty"`
+	ITunesSeason      string `xml:"itunes:season,omitempty" json:"itunesSeason,omitempty"`
+	ITunesEpisodeType string `xml:"itunes:episodeType,omitempty" json:"itunesEpisodeType,omitempty"`
+	ITunesBlock       *YesNo `xml:"itunes:block,omitempty" json:"itunesBlock,omitempty"`
 
 	// Other Fields
+	PodcastFundings            []PodcastFunding            `xml:"podcast:funding,omitempty" json:"podcastFundings,omitempty"`
+	PodcastAlternateEnclosures []PodcastAlternateEnclosure `xml:"podcast:alternateEnclosure,omitempty" json:"podcastAlternateEnclosures,omitempty"`
+	ITunesTitle                string                      `xml:"itunes:title,omitempty" json:"itunesTitle,omitempty"`
+	ITunesAuthor               string                      `xml:"itunes:author,omitempty" json:"itunesAuthor,omitempty"`
+	ITunesKeywords             Keywords                    `xml:"itunes:keywords,omitempty" json:"itunesKeywords,omitempty"`
+	ITunesOrder                Int                         `xml:"itunes:order,omitempty" json:"itunesOrder,omitempty"`
 	// TODO itunes, podcast index namespace
+
+	// Google Play fields, see Parser.GooglePlayFallback
+	GooglePlayAuthor      string           `xml:"googleplay:author,omitempty" json:"googlePlayAuthor,omitempty"`
+	GooglePlayDescription string           `xml:"googleplay:description,omitempty" json:"googlePlayDescription,omitempty"`
+	GooglePlayImage       *GooglePlayImage `xml:"googleplay:image,omitempty" json:"googlePlayImage,omitempty"`
+	GooglePlayExplicit    Explicit         `xml:"googleplay:explicit,omitempty" json:"googlePlayExplicit,omitempty"`
+	GooglePlayBlock       *YesNo           `xml:"googleplay:block,omitempty" json:"googlePlayBlock,omitempty"`
+
+	// Media RSS fields, mostly used by video podcasts. Title, description,
+	// thumbnails, ratings and credits given here apply to all the item's
+	// media, unless overridden in a group or content.
+	MediaContents    []MediaContent   `xml:"media:content,omitempty" json:"mediaContents,omitempty"`
+	MediaGroups      []MediaGroup     `xml:"media:group,omitempty" json:"mediaGroups,omitempty"`
+	MediaTitle       *MediaText       `xml:"media:title,omitempty" json:"mediaTitle,omitempty"`
+	MediaDescription *MediaText       `xml:"media:description,omitempty" json:"mediaDescription,omitempty"`
+	MediaThumbnails  []MediaThumbnail `xml:"media:thumbnail,omitempty" json:"mediaThumbnails,omitempty"`
+	MediaRatings     []MediaRating    `xml:"media:rating,omitempty" json:"mediaRatings,omitempty"`
+	MediaCredits     []MediaCredit    `xml:"media:credit,omitempty" json:"mediaCredits,omitempty"`
+
+	// Dublin Core fields, see Item.Date and Item.Creator. DCDate is written
+	// in ISO 8601 format, see Time.
+	DCCreator string `xml:"dc:creator,omitempty" json:"dcCreator,omitempty"`
+	DCDate    *Time  `xml:"dc:date,omitempty" json:"dcDate,omitempty"`
+
+	// Podlove Simple Chapters fields, see PSCChapters.JSONChapters
+	PSCChapters *PSCChapters `xml:"psc:chapters,omitempty" json:"pscChapters,omitempty"`
+
+	// RSS 2.0 fields
+	Author   string     `xml:"author,omitempty" json:"author,omitempty"`
+	Category []Category `xml:"category,omitempty" json:"category,omitempty"`
+	Comments string     `xml:"comments,omitempty" json:"comments,omitempty"`
+	Source   *Source    `xml:"source,omitempty" json:"source,omitempty"`
+
+	// UnknownElements and UnknownAttrs hold any elements and attributes
+	// which aren't modelled above, see Podcast.UnknownElements.
+	UnknownElements []UnknownElement `xml:",any" json:"unknownElements,omitempty"`
+	UnknownAttrs    []xml.Attr       `xml:",any,attr" json:"unknownAttrs,omitempty"`
+
+	// Extensions holds values for namespaces registered with
+	// Parser.RegisterExtension, see Item.Extension.
+	Extensions []Extension `xml:"-" json:"-"`
+}
+
+// Date returns the item's pubDate, or its dc:date if it has no pubDate.
+func (i *Item) Date() *Time {
+	if i.PubDate != nil {
+		return i.PubDate
+	}
+	return i.DCDate
+}
+
+// Creator returns the item's itunes:author, or its dc:creator if it has no
+// itunes:author.
+func (i *Item) Creator() string {
+	if i.ITunesAuthor != "" {
+		return i.ITunesAuthor
+	}
+	return i.DCCreator
+}
+
+// AuthorEmail returns the email address from the item's RSS author, which
+// is commonly in the form "email (Name)".
+func (i *Item) AuthorEmail() string {
+	email, _ := ParseRSSPerson(i.Author)
+	return email
+}
+
+// AuthorName returns the display name from the item's RSS author, which is
+// commonly in the form "email (Name)".
+func (i *Item) AuthorName() string {
+	_, name := ParseRSSPerson(i.Author)
+	return name
+}
+
+// ParseRSSPerson splits a person, as given in RSS author, managingEditor and
+// webMaster elements, into an email address and display name. The forms
+// "email (Name)" and "Name <email>" are supported, as well as just an email
+// or just a name.
+func ParseRSSPerson(s string) (email, name string) {
+	s = strings.TrimSpace(s)
+	if m := rssPersonEmailFirst.FindStringSubmatch(s); m != nil {
+		return m[1], strings.TrimSpace(m[2])
+	}
+	if m := rssPersonNameFirst.FindStringSubmatch(s); m != nil {
+		return m[2], strings.TrimSpace(m[1])
+	}
+	if !strings.ContainsAny(s, " \t") && strings.Contains(s, "@") {
+		return s, ""
+	}
+	return "", s
 }
 
+var (
+	rssPersonEmailFirst = regexp.MustCompile(`^(\S+@\S+)\s*\((.*)\)$`)
+	rssPersonNameFirst  = regexp.MustCompile(`^(.*)<(\S+@[^\s>]+)>$`)
+)
+
+type Source struct {
+	URL  string `xml:"url,attr" json:"url,omitempty"`
+	Text string `xml:",chardata" json:"text,omitempty"`
+}
+
+// Enclosure is an item's media. Its integrity hash, if it has one, is given
+// by an alternate enclosure, see Item.EnclosureIntegrity.
 type Enclosure struct {
-	Length int64  `xml:"length,attr"`
-	Type   string `xml:"type,attr"`
-	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr" json:"length,omitempty"`
+	Type   string `xml:"type,attr" json:"type,omitempty"`
+	URL    string `xml:"url,attr" json:"url,omitempty"`
+}
+
+// PodcastAlternateEnclosure is an alternative version of an item's media,
+// such as a different bitrate or a video, available from one or more
+// sources. Its content can be checked with Parser.VerifyAlternateEnclosure.
+type PodcastAlternateEnclosure struct {
+	Type    string  `xml:"type,attr" json:"type,omitempty"`
+	Length  int64   `xml:"length,attr,omitempty" json:"length,omitempty"`
+	Bitrate float64 `xml:"bitrate,attr,omitempty" json:"bitrate,omitempty"`
+	Height  int     `xml:"height,attr,omitempty" json:"height,omitempty"`
+	Lang    string  `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Title   string  `xml:"title,attr,omitempty" json:"title,omitempty"`
+	Rel     string  `xml:"rel,attr,omitempty" json:"rel,omitempty"`
+	Codecs  string  `xml:"codecs,attr,omitempty" json:"codecs,omitempty"`
+	Default *Bool   `xml:"default,attr,omitempty" json:"default,omitempty"`
+
+	Sources   []PodcastSource   `xml:"podcast:source" json:"sources,omitempty"`
+	Integrity *PodcastIntegrity `xml:"podcast:integrity,omitempty" json:"integrity,omitempty"`
+}
+
+// PodcastSource is a URI from which an alternate enclosure can be
+// downloaded, which may be a torrent or IPFS URI as well as HTTP.
+type PodcastSource struct {
+	URI         string `xml:"uri,attr" json:"uri,omitempty"`
+	ContentType string `xml:"contentType,attr,omitempty" json:"contentType,omitempty"`
+}
+
+type PodcastIntegrity struct {
+	Type  string `xml:"type,attr" json:"type,omitempty"`
+	Value string `xml:"value,attr" json:"value,omitempty"`
+}
+
+// MediaContent is a media object, such as a video in one of several
+// available formats. Multiple formats of the same media are grouped in a
+// MediaGroup.
+type MediaContent struct {
+	URL          string  `xml:"url,attr,omitempty" json:"url,omitempty"`
+	FileSize     int64   `xml:"fileSize,attr,omitempty" json:"fileSize,omitempty"`
+	Type         string  `xml:"type,attr,omitempty" json:"type,omitempty"`
+	Medium       string  `xml:"medium,attr,omitempty" json:"medium,omitempty"`
+	IsDefault    *Bool   `xml:"isDefault,attr,omitempty" json:"isDefault,omitempty"`
+	Expression   string  `xml:"expression,attr,omitempty" json:"expression,omitempty"`
+	Bitrate      float64 `xml:"bitrate,attr,omitempty" json:"bitrate,omitempty"`
+	Framerate    float64 `xml:"framerate,attr,omitempty" json:"framerate,omitempty"`
+	SamplingRate float64 `xml:"samplingrate,attr,omitempty" json:"samplingRate,omitempty"`
+	Channels     int     `xml:"channels,attr,omitempty" json:"channels,omitempty"`
+	Duration     float64 `xml:"duration,attr,omitempty" json:"duration,omitempty"`
+	Height       int     `xml:"height,attr,omitempty" json:"height,omitempty"`
+	Width        int     `xml:"width,attr,omitempty" json:"width,omitempty"`
+	Lang         string  `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+
+	Title       *MediaText       `xml:"media:title,omitempty" json:"title,omitempty"`
+	Description *MediaText       `xml:"media:description,omitempty" json:"description,omitempty"`
+	Thumbnails  []MediaThumbnail `xml:"media:thumbnail,omitempty" json:"thumbnails,omitempty"`
+	Ratings     []MediaRating    `xml:"media:rating,omitempty" json:"ratings,omitempty"`
+	Credits     []MediaCredit    `xml:"media:credit,omitempty" json:"credits,omitempty"`
+	Player      *MediaPlayer     `xml:"media:player,omitempty" json:"player,omitempty"`
+}
+
+type MediaGroup struct {
+	Contents    []MediaContent   `xml:"media:content" json:"contents,omitempty"`
+	Title       *MediaText       `xml:"media:title,omitempty" json:"title,omitempty"`
+	Description *MediaText       `xml:"media:description,omitempty" json:"description,omitempty"`
+	Thumbnails  []MediaThumbnail `xml:"media:thumbnail,omitempty" json:"thumbnails,omitempty"`
+	Ratings     []MediaRating    `xml:"media:rating,omitempty" json:"ratings,omitempty"`
+	Credits     []MediaCredit    `xml:"media:credit,omitempty" json:"credits,omitempty"`
+}
+
+// MediaText is a media:title or media:description, where Type is "plain"
+// (the default) or "html".
+type MediaText struct {
+	Type string `xml:"type,attr,omitempty" json:"type,omitempty"`
+	Text string `xml:",chardata" json:"text,omitempty"`
+}
+
+type MediaThumbnail struct {
+	URL    string `xml:"url,attr" json:"url,omitempty"`
+	Height int    `xml:"height,attr,omitempty" json:"height,omitempty"`
+	Width  int    `xml:"width,attr,omitempty" json:"width,omitempty"`
+	Time   string `xml:"time,attr,omitempty" json:"time,omitempty"`
+}
+
+type MediaRating struct {
+	Scheme string `xml:"scheme,attr,omitempty" json:"scheme,omitempty"`
+	Text   string `xml:",chardata" json:"text,omitempty"`
+}
+
+type MediaCredit struct {
+	Role   string `xml:"role,attr,omitempty" json:"role,omitempty"`
+	Scheme string `xml:"scheme,attr,omitempty" json:"scheme,omitempty"`
+	Text   string `xml:",chardata" json:"text,omitempty"`
+}
+
+type MediaPlayer struct {
+	URL    string `xml:"url,attr" json:"url,omitempty"`
+	Height int    `xml:"height,attr,omitempty" json:"height,omitempty"`
+	Width  int    `xml:"width,attr,omitempty" json:"width,omitempty"`
+}
+
+type PSCChapters struct {
+	Version  string       `xml:"version,attr,omitempty" json:"version,omitempty"`
+	Chapters []PSCChapter `xml:"psc:chapter" json:"chapters,omitempty"`
+}
+
+// PSCChapter is a chapter in PSCChapters. Start is written as normal play
+// time, see NormalPlayTime.
+type PSCChapter struct {
+	Start time.Duration `xml:"start,attr" json:"start,omitempty"`
+	Title string        `xml:"title,attr" json:"title,omitempty"`
+	Href  string        `xml:"href,attr,omitempty" json:"href,omitempty"`
+	Image string        `xml:"image,attr,omitempty" json:"image,omitempty"`
 }
 
 type ItemGUID struct {
-	IsPermaLink *Bool  `xml:"isPermaLink,attr,omitempty"`
-	Text        string `xml:",chardata"`
+	IsPermaLink *Bool  `xml:"isPermaLink,attr,omitempty" json:"isPermaLink,omitempty"`
+	Text        string `xml:",chardata" json:"text,omitempty"`
 }
 
 type PodcastTranscript struct {
-	URL      string `xml:"url,attr"`
-	Type     string `xml:"type,attr"`
-	Rel      string `xml:"rel,attr,omitempty"`
-	Language string `xml:"language,attr,omitempty"`
+	URL      string `xml:"url,attr" json:"url,omitempty"`
+	Type     string `xml:"type,attr" json:"type,omitempty"`
+	Rel      string `xml:"rel,attr,omitempty" json:"rel,omitempty"`
+	Language string `xml:"language,attr,omitempty" json:"language,omitempty"`
 }
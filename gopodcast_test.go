@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"math"
 	"net/http"
 	"os"
 	"path"
@@ -50,8 +51,26 @@ func TestParseFeed_RequiredFieldsOnly(t *testing.T) {
 	assertStr(t, "", podcast.Copyright)
 	assertNil(t, podcast.PodcastText)
 	assertNil(t, podcast.PodcastFunding)
+	assertInt(t, 0, len(podcast.PodcastFundings))
 	assertStr(t, "", podcast.ITunesType)
 	assertNil(t, podcast.ITunesComplete)
+	assertNil(t, podcast.ITunesOwner)
+	assertStr(t, "", podcast.ITunesSummary)
+	assertStr(t, "", podcast.ITunesSubtitle)
+	assertStr(t, "", podcast.ITunesNewFeedURL)
+	assertInt(t, 0, len(podcast.ITunesKeywords))
+	assertStr(t, "", podcast.ITunesApplePodcastsVerify)
+	assertStr(t, "", string(podcast.PubDate))
+	assertStr(t, "", string(podcast.LastBuildDate))
+	assertStr(t, "", string(podcast.TTL))
+	assertStr(t, "", podcast.Generator)
+	assertStr(t, "", podcast.ManagingEditor)
+	assertStr(t, "", podcast.WebMaster)
+	assertStr(t, "", podcast.Docs)
+	assertNil(t, podcast.Image)
+	assertInt(t, 0, len(podcast.Category))
+	assertNil(t, podcast.SkipHours)
+	assertNil(t, podcast.SkipDays)
 
 	// item fields
 	assertInt(t, 2, len(podcast.Items))
@@ -65,6 +84,7 @@ func TestParseFeed_RequiredFieldsOnly(t *testing.T) {
 	assertStr(t, "12345-67890-abcdef", item.GUID.Text)
 
 	// non-required item fields should be zero values
+	assertInt(t, 0, len(item.PodcastAlternateEnclosures))
 	assertStr(t, "", item.Link)
 	assertNil(t, item.PubDate)
 	assertNil(t, item.Description)
@@ -75,6 +95,15 @@ func TestParseFeed_RequiredFieldsOnly(t *testing.T) {
 	assertStr(t, "", item.ITunesEpisode)
 	assertStr(t, "", item.ITunesSeason)
 	assertNil(t, item.ITunesBlock)
+	assertInt(t, 0, len(item.PodcastFundings))
+	assertStr(t, "", item.ITunesTitle)
+	assertStr(t, "", item.ITunesAuthor)
+	assertInt(t, 0, len(item.ITunesKeywords))
+	assertStr(t, "", string(item.ITunesOrder))
+	assertStr(t, "", item.Author)
+	assertInt(t, 0, len(item.Category))
+	assertStr(t, "", item.Comments)
+	assertNil(t, item.Source)
 }
 
 func TestParseFeed_AllFields(t *testing.T) {
@@ -113,8 +142,42 @@ func TestParseFeed_AllFields(t *testing.T) {
 	assertStr(t, "validation", podcast.PodcastText.Purpose)
 	assertStr(t, "Money please", podcast.PodcastFunding.Text)
 	assertStr(t, "http://www.example.com/money", podcast.PodcastFunding.URL)
+	assertInt(t, 2, len(podcast.PodcastFundings))
+	assertStr(t, "Money please", podcast.PodcastFundings[0].Text)
+	assertStr(t, "http://www.example.com/money", podcast.PodcastFundings[0].URL)
+	assertStr(t, "More money please", podcast.PodcastFundings[1].Text)
+	assertStr(t, "http://www.example.com/more-money", podcast.PodcastFundings[1].URL)
 	assertStr(t, "Serialised", podcast.ITunesType)
 	assertBool(t, true, bool(*podcast.ITunesComplete))
+	assertStr(t, "Dr Tester", podcast.ITunesOwner.Name)
+	assertStr(t, "tester@example.com", podcast.ITunesOwner.Email)
+	assertStr(t, "Test podcast summary", podcast.ITunesSummary)
+	assertStr(t, "Test podcast subtitle", podcast.ITunesSubtitle)
+	assertStr(t, "http://www.example.com/new-feed", podcast.ITunesNewFeedURL)
+	assertStr(t, "testing|Podcasts|examples", strings.Join(podcast.ITunesKeywords, "|"))
+	assertStr(t, "abc-123", podcast.ITunesApplePodcastsVerify)
+	assertDate(t, "2024-12-25T09:00:00Z", podcast.PubDate)
+	assertDate(t, "2024-12-26T12:00:00Z", podcast.LastBuildDate)
+	assertIntValue(t, 60, podcast.TTL)
+	assertStr(t, "Test Generator 1.0", podcast.Generator)
+	assertStr(t, "editor@example.com (Ed Itor)", podcast.ManagingEditor)
+	assertStr(t, "webmaster@example.com (Web Master)", podcast.WebMaster)
+	assertStr(t, "https://www.rssboard.org/rss-specification", podcast.Docs)
+	assertStr(t, "http://www.example.com/rss-image.jpg", podcast.Image.URL)
+	assertStr(t, "Test podcast 1", podcast.Image.Title)
+	assertStr(t, "http://www.example.com/podcast-site", podcast.Image.Link)
+	assertIntValue(t, 144, podcast.Image.Width)
+	assertIntValue(t, 100, podcast.Image.Height)
+	assertInt(t, 2, len(podcast.Category))
+	assertStr(t, "Comedy", podcast.Category[0].Text)
+	assertStr(t, "", podcast.Category[0].Domain)
+	assertStr(t, "Drama/Thriller", podcast.Category[1].Text)
+	assertStr(t, "http://www.example.com/categories", podcast.Category[1].Domain)
+	assertInt(t, 2, len(podcast.SkipHours.Hours))
+	assertIntValue(t, 0, podcast.SkipHours.Hours[0])
+	assertIntValue(t, 1, podcast.SkipHours.Hours[1])
+	assertInt(t, 1, len(podcast.SkipDays.Days))
+	assertStr(t, "Sunday", podcast.SkipDays.Days[0])
 
 	// item fields
 	assertInt(t, 1, len(podcast.Items))
@@ -142,6 +205,39 @@ func TestParseFeed_AllFields(t *testing.T) {
 	assertStr(t, "1", item.ITunesEpisode)
 	assertStr(t, "2", item.ITunesSeason)
 	assertBool(t, false, bool(*item.ITunesBlock))
+	assertInt(t, 1, len(item.PodcastFundings))
+	assertStr(t, "Episode money please", item.PodcastFundings[0].Text)
+	assertStr(t, "http://www.example.com/ep-money", item.PodcastFundings[0].URL)
+	assertInt(t, 1, len(item.PodcastAlternateEnclosures))
+	alt := item.PodcastAlternateEnclosures[0]
+	assertStr(t, "audio/opus", alt.Type)
+	assertInt(t, 800, int(alt.Length))
+	assertTrue(t, alt.Bitrate == 64000.5)
+	assertStr(t, "Low bandwidth", alt.Title)
+	assertBool(t, true, bool(*alt.Default))
+	assertStr(t, "sri", alt.Integrity.Type)
+	assertStr(t, "sha384-/b2OdaZ/KfcBpOBAOF4uI5hjA+oQI5IRr5B/y7g1eLPkF8txzmRu/QgZ3YwIjeG9", alt.Integrity.Value)
+	assertInt(t, 2, len(alt.Sources))
+	assertStr(t, "ipfs://QmdwGqd3d2gFPGeJNLLCshdiPert45fMu84552Y4XHTy4y", alt.Sources[0].URI)
+	assertStr(t, "http://www.example.com/episode-1.opus", alt.Sources[1].URI)
+	assertStr(t, "audio/opus", alt.Sources[1].ContentType)
+	assertInt(t, 0, len(item.UnknownElements))
+	assertStr(t, "Episode 1", item.ITunesTitle)
+	assertStr(t, "Dr Guest", item.ITunesAuthor)
+	assertStr(t, "guest", strings.Join(item.ITunesKeywords, "|"))
+	order, ok := item.ITunesOrder.Value()
+	assertBool(t, true, ok)
+	assertInt(t, 3, order)
+	assertStr(t, "guest@example.com (Dr Guest)", item.Author)
+	assertStr(t, "guest@example.com", item.AuthorEmail())
+	assertStr(t, "Dr Guest", item.AuthorName())
+	assertInt(t, 2, len(item.Category))
+	assertStr(t, "Interviews", item.Category[0].Text)
+	assertStr(t, "Science", item.Category[1].Text)
+	assertStr(t, "http://www.example.com/categories", item.Category[1].Domain)
+	assertStr(t, "http://www.example.com/ep-link#comments", item.Comments)
+	assertStr(t, "http://www.example.com/other-feed", item.Source.URL)
+	assertStr(t, "Other feed", item.Source.Text)
 }
 
 func TestWriteFeed_RequiredFieldsOnly(t *testing.T) {
@@ -242,8 +338,42 @@ func TestWriteFeed_AllFields(t *testing.T) {
 			URL:  "http://www.example.com/funding",
 			Text: "Money please",
 		},
+		PodcastFundings: []gopodcast.PodcastFunding{
+			{
+				URL:  "http://www.example.com/funding-2",
+				Text: "More money please",
+			},
+		},
 		ITunesType:     "episodic",
 		ITunesComplete: yesNoPtr(true),
+		ITunesOwner: &gopodcast.ITunesOwner{
+			Name:  "Mr Author",
+			Email: "author@example.com",
+		},
+		ITunesSummary:             "Test summary",
+		ITunesSubtitle:            "Test subtitle",
+		ITunesKeywords:            gopodcast.Keywords{" comedy", "drama", "Comedy"},
+		ITunesApplePodcastsVerify: "abc-123",
+		PubDate:                   dateFromStr("2024-12-25T10:11:12"),
+		LastBuildDate:             dateFromStr("2024-12-25T10:11:12"),
+		TTL:                       gopodcast.NewInt(30),
+		Generator:                 "gopodcast",
+		ManagingEditor:            "editor@example.com (Ed Itor)",
+		WebMaster:                 "webmaster@example.com (Web Master)",
+		Docs:                      "https://www.rssboard.org/rss-specification",
+		Image: &gopodcast.Image{
+			URL:    "http://www.example.com/image.png",
+			Title:  "Test title",
+			Link:   "http://www.example.com/podcast-site",
+			Width:  gopodcast.NewInt(144),
+			Height: gopodcast.NewInt(144),
+		},
+		Category: []gopodcast.Category{
+			{Text: "Comedy"},
+			{Domain: "http://www.example.com/categories", Text: "Drama"},
+		},
+		SkipHours: &gopodcast.SkipHours{Hours: []gopodcast.Int{"3", "4"}},
+		SkipDays:  &gopodcast.SkipDays{Days: []string{"Saturday"}},
 		Items: []*gopodcast.Item{
 			{
 				Title: "A podcast 1",
@@ -284,6 +414,26 @@ func TestWriteFeed_AllFields(t *testing.T) {
 				ITunesSeason:      "2",
 				ITunesEpisodeType: "long",
 				ITunesBlock:       yesNoPtr(false),
+				PodcastFundings: []gopodcast.PodcastFunding{
+					{
+						URL:  "http://www.example.com/ep-funding",
+						Text: "Episode money please",
+					},
+				},
+				ITunesTitle:    "Podcast 1",
+				ITunesAuthor:   "Mr Guest",
+				ITunesKeywords: gopodcast.Keywords{"guest", "interview"},
+				ITunesOrder:    gopodcast.NewInt(1),
+				Author:         "guest@example.com (Mr Guest)",
+				Category: []gopodcast.Category{
+					{Text: "Interviews"},
+					{Domain: "http://www.example.com/categories", Text: "Science"},
+				},
+				Comments: "http://www.example.com/ep-link#comments",
+				Source: &gopodcast.Source{
+					URL:  "http://www.example.com/other-feed",
+					Text: "Other feed",
+				},
 			},
 		},
 	}
@@ -306,6 +456,263 @@ func TestWriteFeed_AllFields(t *testing.T) {
 	)
 }
 
+func TestWriteFeed_LegacyPodcastFundingNotDuplicated(t *testing.T) {
+	parser := gopodcast.NewParser()
+
+	f, err := os.Open("testdata/test-feed-all.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	podcast, err := parser.ParseFeed(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	err = podcast.WriteFeedXML(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	podcast, err = parser.ParseFeed(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertInt(t, 2, len(podcast.PodcastFundings))
+	assertStr(t, "http://www.example.com/money", podcast.PodcastFundings[0].URL)
+	assertStr(t, "http://www.example.com/more-money", podcast.PodcastFundings[1].URL)
+}
+
+func TestItemsByITunesOrder(t *testing.T) {
+	podcast := &gopodcast.Podcast{
+		Items: []*gopodcast.Item{
+			{Title: "A"},
+			{Title: "B", ITunesOrder: gopodcast.NewInt(2)},
+			{Title: "C", ITunesOrder: "first"},
+			{Title: "D", ITunesOrder: gopodcast.NewInt(1)},
+			{Title: "E", ITunesOrder: gopodcast.NewInt(math.MinInt)},
+			{Title: "F", ITunesOrder: gopodcast.NewInt(math.MaxInt)},
+		},
+	}
+
+	items := podcast.ItemsByITunesOrder()
+
+	titles := make([]string, 0, len(items))
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+	assertStr(t, "E,D,B,F,A,C", strings.Join(titles, ","))
+	// original order is unchanged
+	assertStr(t, "A", podcast.Items[0].Title)
+}
+
+func TestParseFeed_InvalidChannelValues(t *testing.T) {
+	feed := `<rss version="2.0"><channel><pubDate>last week</pubDate>` +
+		`<lastBuildDate>2024-13-45</lastBuildDate><ttl>1 hour</ttl>` +
+		`<image><width>144px</width><height> 100 </height></image>` +
+		`<skipHours><hour>midnight</hour><hour>1</hour></skipHours></channel></rss>`
+	podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok := podcast.PubDate.Value()
+	assertBool(t, false, ok)
+	_, ok = podcast.LastBuildDate.Value()
+	assertBool(t, false, ok)
+	_, ok = podcast.TTL.Value()
+	assertBool(t, false, ok)
+	_, ok = podcast.Image.Width.Value()
+	assertBool(t, false, ok)
+	assertIntValue(t, 100, podcast.Image.Height)
+	_, ok = podcast.SkipHours.Hours[0].Value()
+	assertBool(t, false, ok)
+	assertIntValue(t, 1, podcast.SkipHours.Hours[1])
+
+	// invalid values are written back as they were
+	buf := new(bytes.Buffer)
+	if err := podcast.WriteFeedXML(buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"<pubDate>last week</pubDate>", "<ttl>1 hour</ttl>", "<width>144px</width>", "<hour>midnight</hour>"} {
+		assertTrue(t, strings.Contains(buf.String(), s))
+	}
+}
+
+func TestParseFeed_InvalidITunesOrder(t *testing.T) {
+	podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(
+		`<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd"><channel>` +
+			`<item><itunes:order>first</itunes:order></item></channel></rss>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok := podcast.Items[0].ITunesOrder.Value()
+	assertBool(t, false, ok)
+
+	buf := new(bytes.Buffer)
+	if err := podcast.WriteFeedXML(buf); err != nil {
+		t.Fatal(err)
+	}
+	assertTrue(t, strings.Contains(buf.String(), "<itunes:order>first</itunes:order>"))
+}
+
+func TestWriteFeed_ITunesOrderNormalized(t *testing.T) {
+	podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(
+		`<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd"><channel>` +
+			`<item><itunes:order> 03 </itunes:order></item></channel></rss>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertIntValue(t, 3, podcast.Items[0].ITunesOrder)
+
+	buf := new(bytes.Buffer)
+	if err := podcast.WriteFeedXML(buf); err != nil {
+		t.Fatal(err)
+	}
+	assertTrue(t, strings.Contains(buf.String(), "<itunes:order>3</itunes:order>"))
+}
+
+func TestParseRSSPerson(t *testing.T) {
+	testCases := map[string]struct {
+		email string
+		name  string
+	}{
+		"test@example.com (Test Person)": {"test@example.com", "Test Person"},
+		"test@example.com(Test Person)":  {"test@example.com", "Test Person"},
+		"Test Person <test@example.com>": {"test@example.com", "Test Person"},
+		" test@example.com ":             {"test@example.com", ""},
+		"Test Person":                    {"", "Test Person"},
+		"":                               {"", ""},
+	}
+
+	for in, tc := range testCases {
+		t.Run(in, func(t *testing.T) {
+			email, name := gopodcast.ParseRSSPerson(in)
+			assertStr(t, tc.email, email)
+			assertStr(t, tc.name, name)
+		})
+	}
+}
+
+func TestParseFeed_UnknownElements(t *testing.T) {
+	parser := gopodcast.NewParser()
+
+	f, err := os.Open("testdata/test-feed-unknown.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	podcast, err := parser.ParseFeed(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acast := "https://schema.acast.com/1.0/"
+
+	assertInt(t, 1, len(podcast.UnknownAttrs))
+	assertStr(t, acast, podcast.UnknownAttrs[0].Name.Space)
+	assertStr(t, "showId", podcast.UnknownAttrs[0].Name.Local)
+	assertStr(t, "show-123", podcast.UnknownAttrs[0].Value)
+
+	assertInt(t, 4, len(podcast.UnknownElements))
+	assertStr(t, acast, podcast.UnknownElements[0].XMLName.Space)
+	assertStr(t, "showId", podcast.UnknownElements[0].XMLName.Local)
+	assertStr(t, "show-123", podcast.UnknownElements[0].Text)
+	assertStr(t, "title", podcast.UnknownElements[0].After.Local)
+	assertStr(t, "settings", podcast.UnknownElements[1].XMLName.Local)
+	assertStr(t, "abcdef", podcast.UnknownElements[1].Text)
+	assertStr(t, "title", podcast.UnknownElements[1].After.Local)
+	assertStr(t, "http://bbc.co.uk/2009/01/ppgRss", podcast.UnknownElements[2].XMLName.Space)
+	assertStr(t, "5", podcast.UnknownElements[2].Attrs[0].Value)
+	assertStr(t, "description", podcast.UnknownElements[2].After.Local)
+	assertStr(t, "", podcast.UnknownElements[3].XMLName.Space)
+	assertStr(t, "custom", podcast.UnknownElements[3].XMLName.Local)
+
+	item := podcast.Items[0]
+	assertInt(t, 3, len(item.UnknownElements))
+	assertStr(t, "episodeId", item.UnknownElements[0].XMLName.Local)
+	assertStr(t, "", item.UnknownElements[0].After.Local)
+	assertStr(t, "http://fireside.fm/modules/rss/fireside", item.UnknownElements[1].XMLName.Space)
+	assertStr(t, "enclosure", item.UnknownElements[1].After.Local)
+	assertStr(t, "extra", item.UnknownElements[2].XMLName.Local)
+	assertInt(t, 1, len(item.UnknownElements[2].Children))
+	assertStr(t, "nested", item.UnknownElements[2].Children[0].XMLName.Local)
+	assertStr(t, "value", item.UnknownElements[2].Children[0].Text)
+}
+
+func TestParseFeed_NamespacedElementsWithSameName(t *testing.T) {
+	feed := `<rss version="2.0" xmlns:foo="http://www.example.com/foo"><channel>` +
+		`<title>Test podcast 1</title><foo:title>Foo title</foo:title>` +
+		`<item><foo:title>Foo episode</foo:title><title>Test episode 1</title>` +
+		`<foo:extra><foo:title>Nested</foo:title></foo:extra></item>` +
+		`<foo:item><title>Foo item</title></foo:item></channel></rss>`
+	podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "Test podcast 1", podcast.Title)
+	assertInt(t, 2, len(podcast.UnknownElements))
+	assertStr(t, "http://www.example.com/foo", podcast.UnknownElements[0].XMLName.Space)
+	assertStr(t, "title", podcast.UnknownElements[0].XMLName.Local)
+	assertStr(t, "Foo title", podcast.UnknownElements[0].Text)
+	assertStr(t, "title", podcast.UnknownElements[0].After.Local)
+	assertStr(t, "item", podcast.UnknownElements[1].XMLName.Local)
+	assertStr(t, "item", podcast.UnknownElements[1].After.Local)
+
+	assertInt(t, 1, len(podcast.Items))
+	item := podcast.Items[0]
+	assertStr(t, "Test episode 1", item.Title)
+	assertInt(t, 2, len(item.UnknownElements))
+	assertStr(t, "title", item.UnknownElements[0].XMLName.Local)
+	assertStr(t, "Foo episode", item.UnknownElements[0].Text)
+	assertStr(t, "", item.UnknownElements[0].After.Local)
+	assertStr(t, "title", item.UnknownElements[1].Children[0].XMLName.Local)
+
+	buf := &bytes.Buffer{}
+	if err := podcast.WriteFeedXML(buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	assertTrue(t, strings.Contains(out, `<title>Test podcast 1</title><foo:title>Foo title</foo:title>`))
+	assertTrue(t, strings.Contains(out, `<item><foo:title>Foo episode</foo:title><title>Test episode 1</title>`))
+	assertTrue(t, strings.Contains(out, `<foo:extra><foo:title>Nested</foo:title></foo:extra>`))
+}
+
+func TestWriteFeed_UnknownElements(t *testing.T) {
+	parser := gopodcast.NewParser()
+
+	f, err := os.Open("testdata/test-feed-unknown.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	podcast, err := parser.ParseFeed(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	err = podcast.WriteFeedXML(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp, err := os.ReadFile("testdata/test-feed-write-unknown.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(
+		t,
+		strings.TrimSpace(string(exp)),
+		strings.TrimSpace(buf.String()),
+	)
+}
+
 func TestParseFeedFromURL(t *testing.T) {
 	testFeedURL := "https://feeds.captivate.fm/elis-james-and-john-robins/"
 
@@ -366,6 +773,16 @@ func TestParseFeedFromURL_SendsAuthCreds(t *testing.T) {
 	assertStr(t, "Basic dXNlcjE6cGFzc3dvcmQx", interceptTransport.authHeader)
 }
 
+// sampleTopPodcasts is a small set of the top podcasts, between them using
+// the podcast, dc, media and unknown namespaces, for tests which are too slow
+// to run against every top podcast.
+var sampleTopPodcasts = []string{
+	"1791079106.xml",
+	"1788474884.xml",
+	"1792925325.xml",
+	"400203229.xml",
+}
+
 // TestParseFeed_TopPodcasts tests the parser against many different real podcasts,
 // taken from the Apple charts.
 func TestParseFeed_TopPodcasts(t *testing.T) {
@@ -393,6 +810,63 @@ func TestParseFeed_TopPodcasts(t *testing.T) {
 	}
 }
 
+// TestWriteFeed_TopPodcastsRoundTrip tests that real podcasts can be written
+// after parsing without losing any elements.
+func TestWriteFeed_TopPodcastsRoundTrip(t *testing.T) {
+	files, err := os.ReadDir("testdata/top-podcasts")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		if !file.Type().IsRegular() {
+			continue
+		}
+		t.Run(file.Name(), func(t *testing.T) {
+			parser := gopodcast.NewParser()
+			f, err := os.Open(path.Join("testdata/top-podcasts", file.Name()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			podcast, err := parser.ParseFeed(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			buf := &bytes.Buffer{}
+			err = podcast.WriteFeedXML(buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			written, err := parser.ParseFeed(buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkRequiredFeedValuesPresent(t, written)
+			assertInt(t, len(podcast.Items), len(written.Items))
+			assertInt(t, countUnknownElements(podcast), countUnknownElements(written))
+		})
+	}
+}
+
+func countUnknownElements(podcast *gopodcast.Podcast) int {
+	var count func(els []gopodcast.UnknownElement) int
+	count = func(els []gopodcast.UnknownElement) int {
+		n := len(els)
+		for _, el := range els {
+			n += count(el.Children)
+		}
+		return n
+	}
+
+	n := count(podcast.UnknownElements)
+	for _, item := range podcast.Items {
+		n += count(item.UnknownElements)
+	}
+	return n
+}
+
 // checkRequiredFeedValuesPresent does some simple checks to make sure key
 // fields are present in a podcast feed. This is used for running the parser
 // tests across a large number of real podcast feeds.
@@ -441,6 +915,10 @@ func timeFromStr(str string) *gopodcast.Time {
 	return &tt
 }
 
+func dateFromStr(str string) gopodcast.Date {
+	return gopodcast.NewDate(time.Time(*timeFromStr(str)))
+}
+
 // aim is for this library to have no dependencies, hence the assert funcs here
 func assertTrue(t *testing.T, act bool) {
 	t.Helper()
@@ -484,6 +962,24 @@ func assertInt(t *testing.T, exp int, act int) {
 	}
 }
 
+func assertIntValue(t *testing.T, exp int, act gopodcast.Int) {
+	t.Helper()
+	n, ok := act.Value()
+	if !ok {
+		t.Fatalf("expected '%d', got invalid int '%s'", exp, act)
+	}
+	assertInt(t, exp, n)
+}
+
+func assertDate(t *testing.T, exp string, act gopodcast.Date) {
+	t.Helper()
+	d, ok := act.Value()
+	if !ok {
+		t.Fatalf("expected '%s', got invalid date '%s'", exp, act)
+	}
+	assertStr(t, exp, d.UTC().Format(time.RFC3339))
+}
+
 func assertStrNotEmpty(t *testing.T, act string) {
 	t.Helper()
 	if act == "" {
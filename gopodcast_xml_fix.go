@@ -32,23 +32,63 @@ func (s *xmlFixfeed) Translate() *feed {
 }
 
 type xmlFixPodcast struct {
-	AtomLink       xmlFixAtomLink         `xml:"http://www.w3.org/2005/Atom link"`
-	Title          string                 `xml:"title"`
-	Description    xmlFixDescription      `xml:"description"`
-	Link           string                 `xml:"link"`
-	Language       string                 `xml:"language"`
-	ITunesCategory []xmlFixITunesCategory `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd category"`
-	ITunesExplicit Bool                   `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd explicit"`
-	ITunesImage    xmlFixITunesImage      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
-	PodcastLocked  *YesNo                 `xml:"https://podcastindex.org/namespace/1.0 locked,omitempty"`
-	PodcastGUID    string                 `xml:"https://podcastindex.org/namespace/1.0 guid,omitempty"`
-	ITunesAuthor   string                 `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author,omitempty"`
-	Copyright      string                 `xml:"copyright,omitempty"`
-	PodcastText    *xmlFixPodcastText     `xml:"https://podcastindex.org/namespace/1.0 txt,omitempty"`
-	PodcastFunding *xmlFixPodcastFunding  `xml:"https://podcastindex.org/namespace/1.0 funding,omitempty"`
-	ITunesType     string                 `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd type,omitempty"`
-	ITunesComplete *YesNo                 `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd complete,omitempty"`
-	Items          []*xmlFixItem          `xml:"item"`
+	AtomLink                  xmlFixAtomLink             `xml:"http://www.w3.org/2005/Atom link" json:"atomLink,omitempty"`
+	ITunesCategory            []xmlFixITunesCategory     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd category" json:"itunesCategory,omitempty"`
+	ITunesExplicit            Bool                       `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd explicit" json:"itunesExplicit,omitempty"`
+	ITunesImage               xmlFixITunesImage          `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image" json:"itunesImage,omitempty"`
+	PodcastLocked             *YesNo                     `xml:"https://podcastindex.org/namespace/1.0 locked,omitempty" json:"podcastLocked,omitempty"`
+	PodcastGUID               string                     `xml:"https://podcastindex.org/namespace/1.0 guid,omitempty" json:"podcastGUID,omitempty"`
+	ITunesAuthor              string                     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author,omitempty" json:"itunesAuthor,omitempty"`
+	PodcastText               *xmlFixPodcastText         `xml:"https://podcastindex.org/namespace/1.0 txt,omitempty" json:"podcastText,omitempty"`
+	PodcastFundings           []xmlFixPodcastFunding     `xml:"https://podcastindex.org/namespace/1.0 funding,omitempty" json:"podcastFundings,omitempty"`
+	ITunesType                string                     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd type,omitempty" json:"itunesType,omitempty"`
+	ITunesComplete            *YesNo                     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd complete,omitempty" json:"itunesComplete,omitempty"`
+	ITunesOwner               *xmlFixITunesOwner         `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd owner,omitempty" json:"itunesOwner,omitempty"`
+	ITunesSummary             string                     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary,omitempty" json:"itunesSummary,omitempty"`
+	ITunesSubtitle            string                     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd subtitle,omitempty" json:"itunesSubtitle,omitempty"`
+	ITunesNewFeedURL          string                     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd new-feed-url,omitempty" json:"itunesNewFeedURL,omitempty"`
+	ITunesKeywords            Keywords                   `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd keywords,omitempty" json:"itunesKeywords,omitempty"`
+	ITunesApplePodcastsVerify string                     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd applepodcastsverify,omitempty" json:"itunesApplePodcastsVerify,omitempty"`
+	GooglePlayAuthor          string                     `xml:"http://www.google.com/schemas/play-podcasts/1.0 author,omitempty" json:"googlePlayAuthor,omitempty"`
+	GooglePlayDescription     string                     `xml:"http://www.google.com/schemas/play-podcasts/1.0 description,omitempty" json:"googlePlayDescription,omitempty"`
+	GooglePlayImage           *xmlFixGooglePlayImage     `xml:"http://www.google.com/schemas/play-podcasts/1.0 image,omitempty" json:"googlePlayImage,omitempty"`
+	GooglePlayCategory        []xmlFixGooglePlayCategory `xml:"http://www.google.com/schemas/play-podcasts/1.0 category,omitempty" json:"googlePlayCategory,omitempty"`
+	GooglePlayExplicit        Explicit                   `xml:"http://www.google.com/schemas/play-podcasts/1.0 explicit,omitempty" json:"googlePlayExplicit,omitempty"`
+	GooglePlayBlock           *YesNo                     `xml:"http://www.google.com/schemas/play-podcasts/1.0 block,omitempty" json:"googlePlayBlock,omitempty"`
+	GooglePlayOwner           string                     `xml:"http://www.google.com/schemas/play-podcasts/1.0 owner,omitempty" json:"googlePlayOwner,omitempty"`
+	GooglePlayEmail           string                     `xml:"http://www.google.com/schemas/play-podcasts/1.0 email,omitempty" json:"googlePlayEmail,omitempty"`
+	MediaThumbnails           []xmlFixMediaThumbnail     `xml:"http://search.yahoo.com/mrss/ thumbnail,omitempty" json:"mediaThumbnails,omitempty"`
+	MediaRatings              []xmlFixMediaRating        `xml:"http://search.yahoo.com/mrss/ rating,omitempty" json:"mediaRatings,omitempty"`
+	SpotifyLimit              *xmlFixSpotifyLimit        `xml:"http://www.spotify.com/ns/rss limit,omitempty" json:"spotifyLimit,omitempty"`
+	SpotifyCountryOfOrigin    CountryCodes               `xml:"http://www.spotify.com/ns/rss countryOfOrigin,omitempty" json:"spotifyCountryOfOrigin,omitempty"`
+	DCCreator                 string                     `xml:"http://purl.org/dc/elements/1.1/ creator,omitempty" json:"dcCreator,omitempty"`
+	DCDate                    Date                       `xml:"http://purl.org/dc/elements/1.1/ date,omitempty" json:"dcDate,omitempty"`
+	DCLanguage                string                     `xml:"http://purl.org/dc/elements/1.1/ language,omitempty" json:"dcLanguage,omitempty"`
+	SyUpdatePeriod            string                     `xml:"http://purl.org/rss/1.0/modules/syndication/ updatePeriod,omitempty" json:"syUpdatePeriod,omitempty"`
+	SyUpdateFrequency         *int                       `xml:"http://purl.org/rss/1.0/modules/syndication/ updateFrequency,omitempty" json:"syUpdateFrequency,omitempty"`
+	SyUpdateBase              Date                       `xml:"http://purl.org/rss/1.0/modules/syndication/ updateBase,omitempty" json:"syUpdateBase,omitempty"`
+	Title                     string                     `xml:"title" json:"title,omitempty"`
+	Description               xmlFixDescription          `xml:"description" json:"description,omitempty"`
+	Link                      string                     `xml:"link" json:"link,omitempty"`
+	Language                  string                     `xml:"language" json:"language,omitempty"`
+	Copyright                 string                     `xml:"copyright,omitempty" json:"copyright,omitempty"`
+	PubDate                   Date                       `xml:"pubDate,omitempty" json:"pubDate,omitempty"`
+	LastBuildDate             Date                       `xml:"lastBuildDate,omitempty" json:"lastBuildDate,omitempty"`
+	TTL                       Int                        `xml:"ttl,omitempty" json:"ttl,omitempty"`
+	Generator                 string                     `xml:"generator,omitempty" json:"generator,omitempty"`
+	ManagingEditor            string                     `xml:"managingEditor,omitempty" json:"managingEditor,omitempty"`
+	WebMaster                 string                     `xml:"webMaster,omitempty" json:"webMaster,omitempty"`
+	Docs                      string                     `xml:"docs,omitempty" json:"docs,omitempty"`
+	Image                     *xmlFixImage               `xml:"image,omitempty" json:"image,omitempty"`
+	Category                  []xmlFixCategory           `xml:"category,omitempty" json:"category,omitempty"`
+	SkipHours                 *xmlFixSkipHours           `xml:"skipHours,omitempty" json:"skipHours,omitempty"`
+	SkipDays                  *xmlFixSkipDays            `xml:"skipDays,omitempty" json:"skipDays,omitempty"`
+	Items                     []*xmlFixItem              `xml:"item" json:"items,omitempty"`
+	UnknownElements           []UnknownElement           `xml:",any" json:"unknownElements,omitempty"`
+	UnknownAttrs              []xml.Attr                 `xml:",any,attr" json:"unknownAttrs,omitempty"`
+	Namespaces                []Namespace                `xml:"-" json:"namespaces,omitempty"`
+	Extensions                []Extension                `xml:"-" json:"-"`
+	PodcastFunding            *xmlFixPodcastFunding      `xml:"-" json:"-"`
 }
 
 func (s *xmlFixPodcast) Translate() *Podcast {
@@ -77,21 +117,86 @@ func (s *xmlFixPodcast) Translate() *Podcast {
 	r.ITunesAuthor = s.ITunesAuthor
 	r.Copyright = s.Copyright
 	r.PodcastText = s.PodcastText.Translate()
-	r.PodcastFunding = s.PodcastFunding.Translate()
+	vPodcastFundings := make([]PodcastFunding, 0, len(s.PodcastFundings))
+	for _, v := range s.PodcastFundings {
+		x := v.Translate()
+		vPodcastFundings = append(vPodcastFundings, *x)
+	}
+	r.PodcastFundings = vPodcastFundings
 	r.ITunesType = s.ITunesType
 	r.ITunesComplete = s.ITunesComplete
+	r.ITunesOwner = s.ITunesOwner.Translate()
+	r.ITunesSummary = s.ITunesSummary
+	r.ITunesSubtitle = s.ITunesSubtitle
+	r.ITunesNewFeedURL = s.ITunesNewFeedURL
+	r.ITunesKeywords = s.ITunesKeywords
+	r.ITunesApplePodcastsVerify = s.ITunesApplePodcastsVerify
+	r.GooglePlayAuthor = s.GooglePlayAuthor
+	r.GooglePlayDescription = s.GooglePlayDescription
+	r.GooglePlayImage = s.GooglePlayImage.Translate()
+	vGooglePlayCategory := make([]GooglePlayCategory, 0, len(s.GooglePlayCategory))
+	for _, v := range s.GooglePlayCategory {
+		x := v.Translate()
+		vGooglePlayCategory = append(vGooglePlayCategory, *x)
+	}
+	r.GooglePlayCategory = vGooglePlayCategory
+	r.GooglePlayExplicit = s.GooglePlayExplicit
+	r.GooglePlayBlock = s.GooglePlayBlock
+	r.GooglePlayOwner = s.GooglePlayOwner
+	r.GooglePlayEmail = s.GooglePlayEmail
+	vMediaThumbnails := make([]MediaThumbnail, 0, len(s.MediaThumbnails))
+	for _, v := range s.MediaThumbnails {
+		x := v.Translate()
+		vMediaThumbnails = append(vMediaThumbnails, *x)
+	}
+	r.MediaThumbnails = vMediaThumbnails
+	vMediaRatings := make([]MediaRating, 0, len(s.MediaRatings))
+	for _, v := range s.MediaRatings {
+		x := v.Translate()
+		vMediaRatings = append(vMediaRatings, *x)
+	}
+	r.MediaRatings = vMediaRatings
+	r.SpotifyLimit = s.SpotifyLimit.Translate()
+	r.SpotifyCountryOfOrigin = s.SpotifyCountryOfOrigin
+	r.DCCreator = s.DCCreator
+	r.DCDate = s.DCDate
+	r.DCLanguage = s.DCLanguage
+	r.SyUpdatePeriod = s.SyUpdatePeriod
+	r.SyUpdateFrequency = s.SyUpdateFrequency
+	r.SyUpdateBase = s.SyUpdateBase
+	r.PubDate = s.PubDate
+	r.LastBuildDate = s.LastBuildDate
+	r.TTL = s.TTL
+	r.Generator = s.Generator
+	r.ManagingEditor = s.ManagingEditor
+	r.WebMaster = s.WebMaster
+	r.Docs = s.Docs
+	r.Image = s.Image.Translate()
+	vCategory := make([]Category, 0, len(s.Category))
+	for _, v := range s.Category {
+		x := v.Translate()
+		vCategory = append(vCategory, *x)
+	}
+	r.Category = vCategory
+	r.SkipHours = s.SkipHours.Translate()
+	r.SkipDays = s.SkipDays.Translate()
 	vItems := make([]*Item, 0, len(s.Items))
 	for _, v := range s.Items {
 		vItems = append(vItems, v.Translate())
 	}
 	r.Items = vItems
+	r.UnknownElements = s.UnknownElements
+	r.UnknownAttrs = s.UnknownAttrs
+	r.Namespaces = s.Namespaces
+	r.Extensions = s.Extensions
+	r.PodcastFunding = s.PodcastFunding.Translate()
 	return &r
 }
 
 type xmlFixAtomLink struct {
-	Href string `xml:"href,attr"`
-	Rel  string `xml:"rel,attr"`
-	Type string `xml:"type,attr"`
+	Href string `xml:"href,attr" json:"href,omitempty"`
+	Rel  string `xml:"rel,attr" json:"rel,omitempty"`
+	Type string `xml:"type,attr" json:"type,omitempty"`
 }
 
 func (s *xmlFixAtomLink) Translate() *AtomLink {
@@ -106,7 +211,7 @@ func (s *xmlFixAtomLink) Translate() *AtomLink {
 }
 
 type xmlFixDescription struct {
-	Text string `xml:",cdata"`
+	Text string `xml:",cdata" json:"text,omitempty"`
 }
 
 func (s *xmlFixDescription) Translate() *Description {
@@ -119,8 +224,8 @@ func (s *xmlFixDescription) Translate() *Description {
 }
 
 type xmlFixITunesCategory struct {
-	Text        string                `xml:"text,attr"`
-	SubCategory *xmlFixITunesCategory `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd category,omitempty"`
+	SubCategory *xmlFixITunesCategory `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd category,omitempty" json:"subCategory,omitempty"`
+	Text        string                `xml:"text,attr" json:"text,omitempty"`
 }
 
 func (s *xmlFixITunesCategory) Translate() *ITunesCategory {
@@ -134,7 +239,7 @@ func (s *xmlFixITunesCategory) Translate() *ITunesCategory {
 }
 
 type xmlFixITunesImage struct {
-	Href string `xml:"href,attr"`
+	Href string `xml:"href,attr" json:"href,omitempty"`
 }
 
 func (s *xmlFixITunesImage) Translate() *ITunesImage {
@@ -146,9 +251,63 @@ func (s *xmlFixITunesImage) Translate() *ITunesImage {
 	return &r
 }
 
+type xmlFixITunesOwner struct {
+	Name  string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd name" json:"name,omitempty"`
+	Email string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd email" json:"email,omitempty"`
+}
+
+func (s *xmlFixITunesOwner) Translate() *ITunesOwner {
+	if s == nil {
+		return nil
+	}
+	var r ITunesOwner
+	r.Name = s.Name
+	r.Email = s.Email
+	return &r
+}
+
+type xmlFixGooglePlayImage struct {
+	Href string `xml:"href,attr" json:"href,omitempty"`
+}
+
+func (s *xmlFixGooglePlayImage) Translate() *GooglePlayImage {
+	if s == nil {
+		return nil
+	}
+	var r GooglePlayImage
+	r.Href = s.Href
+	return &r
+}
+
+type xmlFixGooglePlayCategory struct {
+	Text string `xml:"text,attr" json:"text,omitempty"`
+}
+
+func (s *xmlFixGooglePlayCategory) Translate() *GooglePlayCategory {
+	if s == nil {
+		return nil
+	}
+	var r GooglePlayCategory
+	r.Text = s.Text
+	return &r
+}
+
+type xmlFixSpotifyLimit struct {
+	RecentCount int `xml:"recentCount,attr" json:"recentCount,omitempty"`
+}
+
+func (s *xmlFixSpotifyLimit) Translate() *SpotifyLimit {
+	if s == nil {
+		return nil
+	}
+	var r SpotifyLimit
+	r.RecentCount = s.RecentCount
+	return &r
+}
+
 type xmlFixPodcastText struct {
-	Purpose string `xml:"purpose,attr,omitempty"`
-	Text    string `xml:",chardata"`
+	Purpose string `xml:"purpose,attr,omitempty" json:"purpose,omitempty"`
+	Text    string `xml:",chardata" json:"text,omitempty"`
 }
 
 func (s *xmlFixPodcastText) Translate() *PodcastText {
@@ -162,8 +321,8 @@ func (s *xmlFixPodcastText) Translate() *PodcastText {
 }
 
 type xmlFixPodcastFunding struct {
-	URL  string `xml:"url,attr"`
-	Text string `xml:",chardata"`
+	URL  string `xml:"url,attr" json:"url,omitempty"`
+	Text string `xml:",chardata" json:"text,omitempty"`
 }
 
 func (s *xmlFixPodcastFunding) Translate() *PodcastFunding {
@@ -176,21 +335,111 @@ func (s *xmlFixPodcastFunding) Translate() *PodcastFunding {
 	return &r
 }
 
+type xmlFixImage struct {
+	URL    string `xml:"url" json:"url,omitempty"`
+	Title  string `xml:"title" json:"title,omitempty"`
+	Link   string `xml:"link" json:"link,omitempty"`
+	Width  Int    `xml:"width,omitempty" json:"width,omitempty"`
+	Height Int    `xml:"height,omitempty" json:"height,omitempty"`
+}
+
+func (s *xmlFixImage) Translate() *Image {
+	if s == nil {
+		return nil
+	}
+	var r Image
+	r.URL = s.URL
+	r.Title = s.Title
+	r.Link = s.Link
+	r.Width = s.Width
+	r.Height = s.Height
+	return &r
+}
+
+type xmlFixCategory struct {
+	Domain string `xml:"domain,attr,omitempty" json:"domain,omitempty"`
+	Text   string `xml:",chardata" json:"text,omitempty"`
+}
+
+func (s *xmlFixCategory) Translate() *Category {
+	if s == nil {
+		return nil
+	}
+	var r Category
+	r.Domain = s.Domain
+	r.Text = s.Text
+	return &r
+}
+
+type xmlFixSkipHours struct {
+	Hours []Int `xml:"hour" json:"hours,omitempty"`
+}
+
+func (s *xmlFixSkipHours) Translate() *SkipHours {
+	if s == nil {
+		return nil
+	}
+	var r SkipHours
+	r.Hours = s.Hours
+	return &r
+}
+
+type xmlFixSkipDays struct {
+	Days []string `xml:"day" json:"days,omitempty"`
+}
+
+func (s *xmlFixSkipDays) Translate() *SkipDays {
+	if s == nil {
+		return nil
+	}
+	var r SkipDays
+	r.Days = s.Days
+	return &r
+}
+
 type xmlFixItem struct {
-	Title             string                    `xml:"title"`
-	Enclosure         xmlFixEnclosure           `xml:"enclosure"`
-	GUID              xmlFixItemGUID            `xml:"guid"`
-	Link              string                    `xml:"link,omitempty"`
-	PubDate           *Time                     `xml:"pubDate,omitempty"`
-	Description       *xmlFixDescription        `xml:"description,omitempty"`
-	ITunesDuration    string                    `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration,omitempty"`
-	ITunesImage       *xmlFixITunesImage        `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image,omitempty"`
-	ITunesExplicit    *Bool                     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd explicit,omitempty"`
-	PodcastTranscript []xmlFixPodcastTranscript `xml:"https://podcastindex.org/namespace/1.0 transcript,omitempty"`
-	ITunesEpisode     string                    `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episode,omitempty"`
-	ITunesSeason      string                    `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd season,omitempty"`
-	ITunesEpisodeType string                    `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episodeType,omitempty"`
-	ITunesBlock       *YesNo                    `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd block,omitempty"`
+	ITunesDuration             string                            `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration,omitempty" json:"itunesDuration,omitempty"`
+	ITunesImage                *xmlFixITunesImage                `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image,omitempty" json:"itunesImage,omitempty"`
+	ITunesExplicit             *Bool                             `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd explicit,omitempty" json:"itunesExplicit,omitempty"`
+	PodcastTranscript          []xmlFixPodcastTranscript         `xml:"https://podcastindex.org/namespace/1.0 transcript,omitempty" json:"podcastTranscript,omitempty"`
+	ITunesEpisode              string                            `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episode,omitempty" json:"itunesEpisode,omitempty"`
+	ITunesSeason               string                            `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd season,omitempty" json:"itunesSeason,omitempty"`
+	ITunesEpisodeType          string                            `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd episodeType,omitempty" json:"itunesEpisodeType,omitempty"`
+	ITunesBlock                *YesNo                            `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd block,omitempty" json:"itunesBlock,omitempty"`
+	PodcastFundings            []xmlFixPodcastFunding            `xml:"https://podcastindex.org/namespace/1.0 funding,omitempty" json:"podcastFundings,omitempty"`
+	PodcastAlternateEnclosures []xmlFixPodcastAlternateEnclosure `xml:"https://podcastindex.org/namespace/1.0 alternateEnclosure,omitempty" json:"podcastAlternateEnclosures,omitempty"`
+	ITunesTitle                string                            `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd title,omitempty" json:"itunesTitle,omitempty"`
+	ITunesAuthor               string                            `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author,omitempty" json:"itunesAuthor,omitempty"`
+	ITunesKeywords             Keywords                          `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd keywords,omitempty" json:"itunesKeywords,omitempty"`
+	ITunesOrder                Int                               `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd order,omitempty" json:"itunesOrder,omitempty"`
+	GooglePlayAuthor           string                            `xml:"http://www.google.com/schemas/play-podcasts/1.0 author,omitempty" json:"googlePlayAuthor,omitempty"`
+	GooglePlayDescription      string                            `xml:"http://www.google.com/schemas/play-podcasts/1.0 description,omitempty" json:"googlePlayDescription,omitempty"`
+	GooglePlayImage            *xmlFixGooglePlayImage            `xml:"http://www.google.com/schemas/play-podcasts/1.0 image,omitempty" json:"googlePlayImage,omitempty"`
+	GooglePlayExplicit         Explicit                          `xml:"http://www.google.com/schemas/play-podcasts/1.0 explicit,omitempty" json:"googlePlayExplicit,omitempty"`
+	GooglePlayBlock            *YesNo                            `xml:"http://www.google.com/schemas/play-podcasts/1.0 block,omitempty" json:"googlePlayBlock,omitempty"`
+	MediaContents              []xmlFixMediaContent              `xml:"http://search.yahoo.com/mrss/ content,omitempty" json:"mediaContents,omitempty"`
+	MediaGroups                []xmlFixMediaGroup                `xml:"http://search.yahoo.com/mrss/ group,omitempty" json:"mediaGroups,omitempty"`
+	MediaTitle                 *xmlFixMediaText                  `xml:"http://search.yahoo.com/mrss/ title,omitempty" json:"mediaTitle,omitempty"`
+	MediaDescription           *xmlFixMediaText                  `xml:"http://search.yahoo.com/mrss/ description,omitempty" json:"mediaDescription,omitempty"`
+	MediaThumbnails            []xmlFixMediaThumbnail            `xml:"http://search.yahoo.com/mrss/ thumbnail,omitempty" json:"mediaThumbnails,omitempty"`
+	MediaRatings               []xmlFixMediaRating               `xml:"http://search.yahoo.com/mrss/ rating,omitempty" json:"mediaRatings,omitempty"`
+	MediaCredits               []xmlFixMediaCredit               `xml:"http://search.yahoo.com/mrss/ credit,omitempty" json:"mediaCredits,omitempty"`
+	DCCreator                  string                            `xml:"http://purl.org/dc/elements/1.1/ creator,omitempty" json:"dcCreator,omitempty"`
+	DCDate                     *Time                             `xml:"http://purl.org/dc/elements/1.1/ date,omitempty" json:"dcDate,omitempty"`
+	PSCChapters                *xmlFixPSCChapters                `xml:"http://podlove.org/simple-chapters chapters,omitempty" json:"pscChapters,omitempty"`
+	Title                      string                            `xml:"title" json:"title,omitempty"`
+	Enclosure                  xmlFixEnclosure                   `xml:"enclosure" json:"enclosure,omitempty"`
+	GUID                       xmlFixItemGUID                    `xml:"guid" json:"guid,omitempty"`
+	Link                       string                            `xml:"link,omitempty" json:"link,omitempty"`
+	PubDate                    *Time                             `xml:"pubDate,omitempty" json:"pubDate,omitempty"`
+	Description                *xmlFixDescription                `xml:"description,omitempty" json:"description,omitempty"`
+	Author                     string                            `xml:"author,omitempty" json:"author,omitempty"`
+	Category                   []xmlFixCategory                  `xml:"category,omitempty" json:"category,omitempty"`
+	Comments                   string                            `xml:"comments,omitempty" json:"comments,omitempty"`
+	Source                     *xmlFixSource                     `xml:"source,omitempty" json:"source,omitempty"`
+	UnknownElements            []UnknownElement                  `xml:",any" json:"unknownElements,omitempty"`
+	UnknownAttrs               []xml.Attr                        `xml:",any,attr" json:"unknownAttrs,omitempty"`
+	Extensions                 []Extension                       `xml:"-" json:"-"`
 }
 
 func (s *xmlFixItem) Translate() *Item {
@@ -219,13 +468,96 @@ func (s *xmlFixItem) Translate() *Item {
 	r.ITunesSeason = s.ITunesSeason
 	r.ITunesEpisodeType = s.ITunesEpisodeType
 	r.ITunesBlock = s.ITunesBlock
+	vPodcastFundings := make([]PodcastFunding, 0, len(s.PodcastFundings))
+	for _, v := range s.PodcastFundings {
+		x := v.Translate()
+		vPodcastFundings = append(vPodcastFundings, *x)
+	}
+	r.PodcastFundings = vPodcastFundings
+	vPodcastAlternateEnclosures := make([]PodcastAlternateEnclosure, 0, len(s.PodcastAlternateEnclosures))
+	for _, v := range s.PodcastAlternateEnclosures {
+		x := v.Translate()
+		vPodcastAlternateEnclosures = append(vPodcastAlternateEnclosures, *x)
+	}
+	r.PodcastAlternateEnclosures = vPodcastAlternateEnclosures
+	r.ITunesTitle = s.ITunesTitle
+	r.ITunesAuthor = s.ITunesAuthor
+	r.ITunesKeywords = s.ITunesKeywords
+	r.ITunesOrder = s.ITunesOrder
+	r.GooglePlayAuthor = s.GooglePlayAuthor
+	r.GooglePlayDescription = s.GooglePlayDescription
+	r.GooglePlayImage = s.GooglePlayImage.Translate()
+	r.GooglePlayExplicit = s.GooglePlayExplicit
+	r.GooglePlayBlock = s.GooglePlayBlock
+	vMediaContents := make([]MediaContent, 0, len(s.MediaContents))
+	for _, v := range s.MediaContents {
+		x := v.Translate()
+		vMediaContents = append(vMediaContents, *x)
+	}
+	r.MediaContents = vMediaContents
+	vMediaGroups := make([]MediaGroup, 0, len(s.MediaGroups))
+	for _, v := range s.MediaGroups {
+		x := v.Translate()
+		vMediaGroups = append(vMediaGroups, *x)
+	}
+	r.MediaGroups = vMediaGroups
+	r.MediaTitle = s.MediaTitle.Translate()
+	r.MediaDescription = s.MediaDescription.Translate()
+	vMediaThumbnails := make([]MediaThumbnail, 0, len(s.MediaThumbnails))
+	for _, v := range s.MediaThumbnails {
+		x := v.Translate()
+		vMediaThumbnails = append(vMediaThumbnails, *x)
+	}
+	r.MediaThumbnails = vMediaThumbnails
+	vMediaRatings := make([]MediaRating, 0, len(s.MediaRatings))
+	for _, v := range s.MediaRatings {
+		x := v.Translate()
+		vMediaRatings = append(vMediaRatings, *x)
+	}
+	r.MediaRatings = vMediaRatings
+	vMediaCredits := make([]MediaCredit, 0, len(s.MediaCredits))
+	for _, v := range s.MediaCredits {
+		x := v.Translate()
+		vMediaCredits = append(vMediaCredits, *x)
+	}
+	r.MediaCredits = vMediaCredits
+	r.DCCreator = s.DCCreator
+	r.DCDate = s.DCDate
+	r.PSCChapters = s.PSCChapters.Translate()
+	r.Author = s.Author
+	vCategory := make([]Category, 0, len(s.Category))
+	for _, v := range s.Category {
+		x := v.Translate()
+		vCategory = append(vCategory, *x)
+	}
+	r.Category = vCategory
+	r.Comments = s.Comments
+	r.Source = s.Source.Translate()
+	r.UnknownElements = s.UnknownElements
+	r.UnknownAttrs = s.UnknownAttrs
+	r.Extensions = s.Extensions
+	return &r
+}
+
+type xmlFixSource struct {
+	URL  string `xml:"url,attr" json:"url,omitempty"`
+	Text string `xml:",chardata" json:"text,omitempty"`
+}
+
+func (s *xmlFixSource) Translate() *Source {
+	if s == nil {
+		return nil
+	}
+	var r Source
+	r.URL = s.URL
+	r.Text = s.Text
 	return &r
 }
 
 type xmlFixEnclosure struct {
-	Length int64  `xml:"length,attr"`
-	Type   string `xml:"type,attr"`
-	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr" json:"length,omitempty"`
+	Type   string `xml:"type,attr" json:"type,omitempty"`
+	URL    string `xml:"url,attr" json:"url,omitempty"`
 }
 
 func (s *xmlFixEnclosure) Translate() *Enclosure {
@@ -239,9 +571,284 @@ func (s *xmlFixEnclosure) Translate() *Enclosure {
 	return &r
 }
 
+type xmlFixPodcastAlternateEnclosure struct {
+	Sources   []xmlFixPodcastSource   `xml:"https://podcastindex.org/namespace/1.0 source" json:"sources,omitempty"`
+	Integrity *xmlFixPodcastIntegrity `xml:"https://podcastindex.org/namespace/1.0 integrity,omitempty" json:"integrity,omitempty"`
+	Type      string                  `xml:"type,attr" json:"type,omitempty"`
+	Length    int64                   `xml:"length,attr,omitempty" json:"length,omitempty"`
+	Bitrate   float64                 `xml:"bitrate,attr,omitempty" json:"bitrate,omitempty"`
+	Height    int                     `xml:"height,attr,omitempty" json:"height,omitempty"`
+	Lang      string                  `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Title     string                  `xml:"title,attr,omitempty" json:"title,omitempty"`
+	Rel       string                  `xml:"rel,attr,omitempty" json:"rel,omitempty"`
+	Codecs    string                  `xml:"codecs,attr,omitempty" json:"codecs,omitempty"`
+	Default   *Bool                   `xml:"default,attr,omitempty" json:"default,omitempty"`
+}
+
+func (s *xmlFixPodcastAlternateEnclosure) Translate() *PodcastAlternateEnclosure {
+	if s == nil {
+		return nil
+	}
+	var r PodcastAlternateEnclosure
+	r.Type = s.Type
+	r.Length = s.Length
+	r.Bitrate = s.Bitrate
+	r.Height = s.Height
+	r.Lang = s.Lang
+	r.Title = s.Title
+	r.Rel = s.Rel
+	r.Codecs = s.Codecs
+	r.Default = s.Default
+	vSources := make([]PodcastSource, 0, len(s.Sources))
+	for _, v := range s.Sources {
+		x := v.Translate()
+		vSources = append(vSources, *x)
+	}
+	r.Sources = vSources
+	r.Integrity = s.Integrity.Translate()
+	return &r
+}
+
+type xmlFixPodcastSource struct {
+	URI         string `xml:"uri,attr" json:"uri,omitempty"`
+	ContentType string `xml:"contentType,attr,omitempty" json:"contentType,omitempty"`
+}
+
+func (s *xmlFixPodcastSource) Translate() *PodcastSource {
+	if s == nil {
+		return nil
+	}
+	var r PodcastSource
+	r.URI = s.URI
+	r.ContentType = s.ContentType
+	return &r
+}
+
+type xmlFixPodcastIntegrity struct {
+	Type  string `xml:"type,attr" json:"type,omitempty"`
+	Value string `xml:"value,attr" json:"value,omitempty"`
+}
+
+func (s *xmlFixPodcastIntegrity) Translate() *PodcastIntegrity {
+	if s == nil {
+		return nil
+	}
+	var r PodcastIntegrity
+	r.Type = s.Type
+	r.Value = s.Value
+	return &r
+}
+
+type xmlFixMediaContent struct {
+	Title        *xmlFixMediaText       `xml:"http://search.yahoo.com/mrss/ title,omitempty" json:"title,omitempty"`
+	Description  *xmlFixMediaText       `xml:"http://search.yahoo.com/mrss/ description,omitempty" json:"description,omitempty"`
+	Thumbnails   []xmlFixMediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail,omitempty" json:"thumbnails,omitempty"`
+	Ratings      []xmlFixMediaRating    `xml:"http://search.yahoo.com/mrss/ rating,omitempty" json:"ratings,omitempty"`
+	Credits      []xmlFixMediaCredit    `xml:"http://search.yahoo.com/mrss/ credit,omitempty" json:"credits,omitempty"`
+	Player       *xmlFixMediaPlayer     `xml:"http://search.yahoo.com/mrss/ player,omitempty" json:"player,omitempty"`
+	URL          string                 `xml:"url,attr,omitempty" json:"url,omitempty"`
+	FileSize     int64                  `xml:"fileSize,attr,omitempty" json:"fileSize,omitempty"`
+	Type         string                 `xml:"type,attr,omitempty" json:"type,omitempty"`
+	Medium       string                 `xml:"medium,attr,omitempty" json:"medium,omitempty"`
+	IsDefault    *Bool                  `xml:"isDefault,attr,omitempty" json:"isDefault,omitempty"`
+	Expression   string                 `xml:"expression,attr,omitempty" json:"expression,omitempty"`
+	Bitrate      float64                `xml:"bitrate,attr,omitempty" json:"bitrate,omitempty"`
+	Framerate    float64                `xml:"framerate,attr,omitempty" json:"framerate,omitempty"`
+	SamplingRate float64                `xml:"samplingrate,attr,omitempty" json:"samplingRate,omitempty"`
+	Channels     int                    `xml:"channels,attr,omitempty" json:"channels,omitempty"`
+	Duration     float64                `xml:"duration,attr,omitempty" json:"duration,omitempty"`
+	Height       int                    `xml:"height,attr,omitempty" json:"height,omitempty"`
+	Width        int                    `xml:"width,attr,omitempty" json:"width,omitempty"`
+	Lang         string                 `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+}
+
+func (s *xmlFixMediaContent) Translate() *MediaContent {
+	if s == nil {
+		return nil
+	}
+	var r MediaContent
+	r.URL = s.URL
+	r.FileSize = s.FileSize
+	r.Type = s.Type
+	r.Medium = s.Medium
+	r.IsDefault = s.IsDefault
+	r.Expression = s.Expression
+	r.Bitrate = s.Bitrate
+	r.Framerate = s.Framerate
+	r.SamplingRate = s.SamplingRate
+	r.Channels = s.Channels
+	r.Duration = s.Duration
+	r.Height = s.Height
+	r.Width = s.Width
+	r.Lang = s.Lang
+	r.Title = s.Title.Translate()
+	r.Description = s.Description.Translate()
+	vThumbnails := make([]MediaThumbnail, 0, len(s.Thumbnails))
+	for _, v := range s.Thumbnails {
+		x := v.Translate()
+		vThumbnails = append(vThumbnails, *x)
+	}
+	r.Thumbnails = vThumbnails
+	vRatings := make([]MediaRating, 0, len(s.Ratings))
+	for _, v := range s.Ratings {
+		x := v.Translate()
+		vRatings = append(vRatings, *x)
+	}
+	r.Ratings = vRatings
+	vCredits := make([]MediaCredit, 0, len(s.Credits))
+	for _, v := range s.Credits {
+		x := v.Translate()
+		vCredits = append(vCredits, *x)
+	}
+	r.Credits = vCredits
+	r.Player = s.Player.Translate()
+	return &r
+}
+
+type xmlFixMediaGroup struct {
+	Contents    []xmlFixMediaContent   `xml:"http://search.yahoo.com/mrss/ content" json:"contents,omitempty"`
+	Title       *xmlFixMediaText       `xml:"http://search.yahoo.com/mrss/ title,omitempty" json:"title,omitempty"`
+	Description *xmlFixMediaText       `xml:"http://search.yahoo.com/mrss/ description,omitempty" json:"description,omitempty"`
+	Thumbnails  []xmlFixMediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail,omitempty" json:"thumbnails,omitempty"`
+	Ratings     []xmlFixMediaRating    `xml:"http://search.yahoo.com/mrss/ rating,omitempty" json:"ratings,omitempty"`
+	Credits     []xmlFixMediaCredit    `xml:"http://search.yahoo.com/mrss/ credit,omitempty" json:"credits,omitempty"`
+}
+
+func (s *xmlFixMediaGroup) Translate() *MediaGroup {
+	if s == nil {
+		return nil
+	}
+	var r MediaGroup
+	vContents := make([]MediaContent, 0, len(s.Contents))
+	for _, v := range s.Contents {
+		x := v.Translate()
+		vContents = append(vContents, *x)
+	}
+	r.Contents = vContents
+	r.Title = s.Title.Translate()
+	r.Description = s.Description.Translate()
+	vThumbnails := make([]MediaThumbnail, 0, len(s.Thumbnails))
+	for _, v := range s.Thumbnails {
+		x := v.Translate()
+		vThumbnails = append(vThumbnails, *x)
+	}
+	r.Thumbnails = vThumbnails
+	vRatings := make([]MediaRating, 0, len(s.Ratings))
+	for _, v := range s.Ratings {
+		x := v.Translate()
+		vRatings = append(vRatings, *x)
+	}
+	r.Ratings = vRatings
+	vCredits := make([]MediaCredit, 0, len(s.Credits))
+	for _, v := range s.Credits {
+		x := v.Translate()
+		vCredits = append(vCredits, *x)
+	}
+	r.Credits = vCredits
+	return &r
+}
+
+type xmlFixMediaText struct {
+	Type string `xml:"type,attr,omitempty" json:"type,omitempty"`
+	Text string `xml:",chardata" json:"text,omitempty"`
+}
+
+func (s *xmlFixMediaText) Translate() *MediaText {
+	if s == nil {
+		return nil
+	}
+	var r MediaText
+	r.Type = s.Type
+	r.Text = s.Text
+	return &r
+}
+
+type xmlFixMediaThumbnail struct {
+	URL    string `xml:"url,attr" json:"url,omitempty"`
+	Height int    `xml:"height,attr,omitempty" json:"height,omitempty"`
+	Width  int    `xml:"width,attr,omitempty" json:"width,omitempty"`
+	Time   string `xml:"time,attr,omitempty" json:"time,omitempty"`
+}
+
+func (s *xmlFixMediaThumbnail) Translate() *MediaThumbnail {
+	if s == nil {
+		return nil
+	}
+	var r MediaThumbnail
+	r.URL = s.URL
+	r.Height = s.Height
+	r.Width = s.Width
+	r.Time = s.Time
+	return &r
+}
+
+type xmlFixMediaRating struct {
+	Scheme string `xml:"scheme,attr,omitempty" json:"scheme,omitempty"`
+	Text   string `xml:",chardata" json:"text,omitempty"`
+}
+
+func (s *xmlFixMediaRating) Translate() *MediaRating {
+	if s == nil {
+		return nil
+	}
+	var r MediaRating
+	r.Scheme = s.Scheme
+	r.Text = s.Text
+	return &r
+}
+
+type xmlFixMediaCredit struct {
+	Role   string `xml:"role,attr,omitempty" json:"role,omitempty"`
+	Scheme string `xml:"scheme,attr,omitempty" json:"scheme,omitempty"`
+	Text   string `xml:",chardata" json:"text,omitempty"`
+}
+
+func (s *xmlFixMediaCredit) Translate() *MediaCredit {
+	if s == nil {
+		return nil
+	}
+	var r MediaCredit
+	r.Role = s.Role
+	r.Scheme = s.Scheme
+	r.Text = s.Text
+	return &r
+}
+
+type xmlFixMediaPlayer struct {
+	URL    string `xml:"url,attr" json:"url,omitempty"`
+	Height int    `xml:"height,attr,omitempty" json:"height,omitempty"`
+	Width  int    `xml:"width,attr,omitempty" json:"width,omitempty"`
+}
+
+func (s *xmlFixMediaPlayer) Translate() *MediaPlayer {
+	if s == nil {
+		return nil
+	}
+	var r MediaPlayer
+	r.URL = s.URL
+	r.Height = s.Height
+	r.Width = s.Width
+	return &r
+}
+
+type xmlFixPSCChapters struct {
+	Chapters []PSCChapter `xml:"http://podlove.org/simple-chapters chapter" json:"chapters,omitempty"`
+	Version  string       `xml:"version,attr,omitempty" json:"version,omitempty"`
+}
+
+func (s *xmlFixPSCChapters) Translate() *PSCChapters {
+	if s == nil {
+		return nil
+	}
+	var r PSCChapters
+	r.Version = s.Version
+	r.Chapters = s.Chapters
+	return &r
+}
+
 type xmlFixItemGUID struct {
-	IsPermaLink *Bool  `xml:"isPermaLink,attr,omitempty"`
-	Text        string `xml:",chardata"`
+	IsPermaLink *Bool  `xml:"isPermaLink,attr,omitempty" json:"isPermaLink,omitempty"`
+	Text        string `xml:",chardata" json:"text,omitempty"`
 }
 
 func (s *xmlFixItemGUID) Translate() *ItemGUID {
@@ -255,10 +862,10 @@ func (s *xmlFixItemGUID) Translate() *ItemGUID {
 }
 
 type xmlFixPodcastTranscript struct {
-	URL      string `xml:"url,attr"`
-	Type     string `xml:"type,attr"`
-	Rel      string `xml:"rel,attr,omitempty"`
-	Language string `xml:"language,attr,omitempty"`
+	URL      string `xml:"url,attr" json:"url,omitempty"`
+	Type     string `xml:"type,attr" json:"type,omitempty"`
+	Rel      string `xml:"rel,attr,omitempty" json:"rel,omitempty"`
+	Language string `xml:"language,attr,omitempty" json:"language,omitempty"`
 }
 
 func (s *xmlFixPodcastTranscript) Translate() *PodcastTranscript {
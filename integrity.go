@@ -0,0 +1,172 @@
+package gopodcast
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ErrIntegrityMismatch is returned when enclosure content does not match the
+// hash given by a podcast:integrity element.
+var ErrIntegrityMismatch = errors.New("enclosure content does not match integrity hash")
+
+// sriAlgorithms are the hash algorithms supported for subresource integrity,
+// in order of strength.
+var sriAlgorithms = []struct {
+	name    string
+	newHash func() hash.Hash
+}{
+	{"sha512", sha512.New},
+	{"sha384", sha512.New384},
+	{"sha256", sha256.New},
+}
+
+// Verify reads r to the end and checks that its content matches the
+// integrity hash, returning ErrIntegrityMismatch if it does not. Only the
+// "sri" integrity type is supported.
+func (i *PodcastIntegrity) Verify(r io.Reader) error {
+	if !strings.EqualFold(i.Type, "sri") {
+		return fmt.Errorf("unsupported integrity type '%s'", i.Type)
+	}
+
+	name, newHash, expected, err := parseSRI(i.Value)
+	if err != nil {
+		return err
+	}
+
+	h := newHash()
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+	sum := h.Sum(nil)
+
+	for _, exp := range expected {
+		if bytes.Equal(sum, exp) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrIntegrityMismatch, name)
+}
+
+// VerifyIntegrity reads r to the end and checks that its content matches the
+// alternate enclosure's podcast:integrity hash, as described by
+// PodcastIntegrity.Verify.
+func (a *PodcastAlternateEnclosure) VerifyIntegrity(r io.Reader) error {
+	if a.Integrity == nil {
+		return errors.New("alternate enclosure has no integrity hash")
+	}
+	return a.Integrity.Verify(r)
+}
+
+// VerifyAlternateEnclosure downloads the alternate enclosure from its first
+// HTTP source, using the parser's http client, and checks its content against
+// the podcast:integrity hash, as described by
+// PodcastAlternateEnclosure.VerifyIntegrity. The content is written to w as
+// it is downloaded, so that it can be stored without a second download; if
+// an error is returned, whatever was written to w must be discarded. w may be
+// nil to only verify the content.
+func (p *Parser) VerifyAlternateEnclosure(ctx context.Context, a *PodcastAlternateEnclosure, w io.Writer) error {
+	if a.Integrity == nil {
+		return errors.New("alternate enclosure has no integrity hash")
+	}
+	i := slices.IndexFunc(a.Sources, func(s PodcastSource) bool {
+		u, err := url.Parse(s.URI)
+		return err == nil && (u.Scheme == "http" || u.Scheme == "https")
+	})
+	if i < 0 {
+		return errors.New("alternate enclosure has no http source")
+	}
+
+	return p.download(ctx, a.Sources[i].URI, a.Integrity, w)
+}
+
+// EnclosureIntegrity returns the podcast:integrity hash of the item's
+// enclosure, or nil if it has none. RSS enclosures can't have an integrity
+// hash, so it is taken from the alternate enclosure which lists the
+// enclosure's URL as a source, or otherwise from the default alternate
+// enclosure, which represents the enclosure.
+func (i *Item) EnclosureIntegrity() *PodcastIntegrity {
+	var def *PodcastIntegrity
+	for _, a := range i.PodcastAlternateEnclosures {
+		if a.Integrity == nil {
+			continue
+		}
+		if slices.ContainsFunc(a.Sources, func(s PodcastSource) bool { return s.URI == i.Enclosure.URL }) {
+			return a.Integrity
+		}
+		if def == nil && a.Default != nil && bool(*a.Default) {
+			def = a.Integrity
+		}
+	}
+	return def
+}
+
+// VerifyEnclosure downloads the item's enclosure, using the parser's http
+// client, and checks its content against the integrity hash returned by
+// Item.EnclosureIntegrity. As with VerifyAlternateEnclosure, the content is
+// written to w, which may be nil, and must be discarded if an error is
+// returned.
+func (p *Parser) VerifyEnclosure(ctx context.Context, item *Item, w io.Writer) error {
+	integrity := item.EnclosureIntegrity()
+	if integrity == nil {
+		return errors.New("enclosure has no integrity hash")
+	}
+	return p.download(ctx, item.Enclosure.URL, integrity, w)
+}
+
+// download downloads url, writing its content to w if set, and verifies it
+// against integrity.
+func (p *Parser) download(ctx context.Context, url string, integrity *PodcastIntegrity, w io.Writer) (err error) {
+	res, err := p.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		errVal := res.Body.Close()
+		if errVal != nil && err == nil {
+			err = errVal
+		}
+	}()
+
+	if w == nil {
+		w = io.Discard
+	}
+	return integrity.Verify(io.TeeReader(res.Body, w))
+}
+
+// parseSRI parses a subresource integrity value, returning the strongest hash
+// algorithm it contains along with every expected digest for that algorithm.
+// Unsupported algorithms are ignored, as required by the SRI spec.
+func parseSRI(value string) (string, func() hash.Hash, [][]byte, error) {
+	digests := make(map[string][][]byte)
+	for _, token := range strings.Fields(value) {
+		alg, b64, ok := strings.Cut(token, "-")
+		if !ok {
+			continue
+		}
+		// options may follow the digest, e.g. "sha256-abc?opt"
+		b64, _, _ = strings.Cut(b64, "?")
+		digest, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("invalid integrity digest '%s'", token)
+		}
+		alg = strings.ToLower(alg)
+		digests[alg] = append(digests[alg], digest)
+	}
+
+	for _, alg := range sriAlgorithms {
+		if d, ok := digests[alg.name]; ok {
+			return alg.name, alg.newHash, d, nil
+		}
+	}
+	return "", nil, nil, fmt.Errorf("no supported hash in integrity value '%s'", value)
+}
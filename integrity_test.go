@@ -0,0 +1,172 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+// hashes of "hello world"
+const (
+	helloWorldSHA256 = "sha256-uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek="
+	helloWorldSHA384 = "sha384-/b2OdaZ/KfcBpOBAOF4uI5hjA+oQI5IRr5B/y7g1eLPkF8txzmRu/QgZ3YwIjeG9"
+)
+
+func TestVerifyIntegrity(t *testing.T) {
+	testCases := map[string]struct {
+		integrity *gopodcast.PodcastIntegrity
+		content   string
+		expErr    string
+	}{
+		"sha256 match": {
+			integrity: &gopodcast.PodcastIntegrity{Type: "sri", Value: helloWorldSHA256},
+			content:   "hello world",
+		},
+		"sha384 match": {
+			integrity: &gopodcast.PodcastIntegrity{Type: "SRI", Value: helloWorldSHA384},
+			content:   "hello world",
+		},
+		"strongest algorithm used": {
+			integrity: &gopodcast.PodcastIntegrity{Type: "sri", Value: helloWorldSHA256 + " sha384-AAAA"},
+			content:   "hello world",
+			expErr:    "enclosure content does not match integrity hash: sha384",
+		},
+		"unsupported algorithms ignored": {
+			integrity: &gopodcast.PodcastIntegrity{Type: "sri", Value: "md5-AAAA " + helloWorldSHA256},
+			content:   "hello world",
+		},
+		"mismatch": {
+			integrity: &gopodcast.PodcastIntegrity{Type: "sri", Value: helloWorldSHA256},
+			content:   "goodbye world",
+			expErr:    "enclosure content does not match integrity hash: sha256",
+		},
+		"unsupported type": {
+			integrity: &gopodcast.PodcastIntegrity{Type: "pgp-signature", Value: "abc"},
+			content:   "hello world",
+			expErr:    "unsupported integrity type 'pgp-signature'",
+		},
+		"no supported hash": {
+			integrity: &gopodcast.PodcastIntegrity{Type: "sri", Value: "md5-AAAA"},
+			content:   "hello world",
+			expErr:    "no supported hash in integrity value 'md5-AAAA'",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.integrity.Verify(strings.NewReader(tc.content))
+			if tc.expErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			assertNotNil(t, err)
+			assertStr(t, tc.expErr, err.Error())
+		})
+	}
+}
+
+func TestVerifyIntegrity_NoIntegrity(t *testing.T) {
+	alt := &gopodcast.PodcastAlternateEnclosure{Type: "audio/mpeg"}
+	err := alt.VerifyIntegrity(strings.NewReader("hello world"))
+	assertNotNil(t, err)
+	assertStr(t, "alternate enclosure has no integrity hash", err.Error())
+}
+
+func TestVerifyAlternateEnclosure(t *testing.T) {
+	alt := &gopodcast.PodcastAlternateEnclosure{
+		Type: "audio/mpeg",
+		Sources: []gopodcast.PodcastSource{
+			{URI: "ipfs://QmdwGqd3d2gFPGeJNLLCshdiPert45fMu84552Y4XHTy4y"},
+			{URI: "http://www.example.com/episode.mp3"},
+		},
+		Integrity: &gopodcast.PodcastIntegrity{Type: "sri", Value: helloWorldSHA384},
+	}
+
+	parser := gopodcast.NewParser()
+
+	// the content is stored while it is verified
+	parser.HTTPClient = newTestClient(200, "hello world")
+	buf := new(bytes.Buffer)
+	err := parser.VerifyAlternateEnclosure(context.Background(), alt, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, "hello world", buf.String())
+
+	parser.HTTPClient = newTestClient(200, "tampered")
+	err = parser.VerifyAlternateEnclosure(context.Background(), alt, nil)
+	assertTrue(t, errors.Is(err, gopodcast.ErrIntegrityMismatch))
+
+	parser.HTTPClient = newTestClient(404, "not found")
+	err = parser.VerifyAlternateEnclosure(context.Background(), alt, nil)
+	assertStr(t, "non-200 http response '404'", err.Error())
+
+	// credentials aren't sent to media hosts
+	transport := &routeTransport{
+		routes:      map[string]testRoute{"http://www.example.com/episode.mp3": {status: 200, body: "hello world"}},
+		authHeaders: make(map[string]string),
+	}
+	parser.HTTPClient = &http.Client{Transport: transport}
+	parser.AuthCredentials = &gopodcast.AuthCredentials{Username: "user1", Password: "password1"}
+	err = parser.VerifyAlternateEnclosure(context.Background(), alt, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, requested := transport.authHeaders["http://www.example.com/episode.mp3"]
+	assertTrue(t, requested)
+	assertStr(t, "", transport.authHeaders["http://www.example.com/episode.mp3"])
+
+	alt.Sources = alt.Sources[:1]
+	err = parser.VerifyAlternateEnclosure(context.Background(), alt, nil)
+	assertStr(t, "alternate enclosure has no http source", err.Error())
+}
+
+func TestVerifyEnclosure(t *testing.T) {
+	yes := gopodcast.Bool(true)
+	item := &gopodcast.Item{
+		Enclosure: gopodcast.Enclosure{URL: "http://www.example.com/episode.mp3", Type: "audio/mpeg"},
+		PodcastAlternateEnclosures: []gopodcast.PodcastAlternateEnclosure{
+			{
+				Type:      "audio/opus",
+				Sources:   []gopodcast.PodcastSource{{URI: "http://www.example.com/episode.opus"}},
+				Integrity: &gopodcast.PodcastIntegrity{Type: "sri", Value: helloWorldSHA256},
+			},
+			{
+				Type:      "audio/mpeg",
+				Sources:   []gopodcast.PodcastSource{{URI: "http://www.example.com/episode.mp3"}},
+				Integrity: &gopodcast.PodcastIntegrity{Type: "sri", Value: helloWorldSHA384},
+			},
+		},
+	}
+
+	// the alternate enclosure with the enclosure's URL is used
+	assertStr(t, helloWorldSHA384, item.EnclosureIntegrity().Value)
+
+	parser := gopodcast.NewParser()
+	parser.HTTPClient = newTestClient(200, "hello world")
+	buf := new(bytes.Buffer)
+	if err := parser.VerifyEnclosure(context.Background(), item, buf); err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, "hello world", buf.String())
+
+	parser.HTTPClient = newTestClient(200, "tampered")
+	err := parser.VerifyEnclosure(context.Background(), item, nil)
+	assertTrue(t, errors.Is(err, gopodcast.ErrIntegrityMismatch))
+
+	// or otherwise the default alternate enclosure
+	item.PodcastAlternateEnclosures[1].Sources[0].URI = "ipfs://QmdwGqd3d2gFPGeJNLLCshdiPert45fMu84552Y4XHTy4y"
+	assertNil(t, item.EnclosureIntegrity())
+	item.PodcastAlternateEnclosures[1].Default = &yes
+	assertStr(t, helloWorldSHA384, item.EnclosureIntegrity().Value)
+
+	err = parser.VerifyEnclosure(context.Background(), &gopodcast.Item{}, nil)
+	assertStr(t, "enclosure has no integrity hash", err.Error())
+}
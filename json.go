@@ -0,0 +1,358 @@
+package gopodcast
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Podcast and Item are marshalled to JSON by encoding/json, using the keys in
+// their json struct tags, so that parsed feeds can be stored and reloaded.
+// Keys are the field names in lower camel case, e.g. "itunesAuthor", and
+// empty values are omitted. The custom value types have the following JSON
+// representations:
+//
+//   - Bool and YesNo are JSON booleans
+//   - Time is an RFC 3339 string, with nanoseconds, followed by the zone
+//     abbreviation if it has one other than UTC, e.g. "2024-12-25T09:00:00Z GMT"
+//   - Keywords and CountryCodes are arrays of strings
+//   - NormalPlayTime, and the Start of a PSCChapter, are strings such as
+//     "01:02:03.500"
+//   - Int, Date and Explicit are strings of the text they were parsed from,
+//     e.g. "3", with valid Int values in their canonical form
+//   - XML names are objects with "space" and "local" keys, and XML attributes
+//     also have a "value" key, e.g. {"local":"rel","value":"self"}
+//
+// Podcast and Item objects also have a jsonVersion key, which is incremented
+// when the representation changes in a way that older versions of this
+// package can't read. Extension values are stored as the unknown elements
+// they were decoded from, and are decoded again by Parser.ParseJSON.
+
+// jsonVersion is the version of the JSON representation of Podcast and Item
+const jsonVersion = 1
+
+type podcastJSON Podcast
+
+func (p Podcast) MarshalJSON() ([]byte, error) {
+	fields := podcastJSON(*p.withLegacyFields())
+	var err error
+	fields.UnknownElements, err = withExtensionElements(fields.UnknownElements, fields.Extensions)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep the prefixes of extension namespaces, which aren't stored
+	fields.Namespaces = withExtensionNamespaces(fields.Namespaces, fields.Extensions)
+	for _, item := range fields.Items {
+		fields.Namespaces = withExtensionNamespaces(fields.Namespaces, item.Extensions)
+	}
+
+	return json.Marshal(struct {
+		JSONVersion int `json:"jsonVersion"`
+		podcastJSON
+		UnknownAttrs []jsonAttr `json:"unknownAttrs,omitempty"`
+	}{jsonVersion, fields, toJSONAttrs(fields.UnknownAttrs)})
+}
+
+func (p *Podcast) UnmarshalJSON(data []byte) error {
+	var v struct {
+		JSONVersion int `json:"jsonVersion"`
+		podcastJSON
+		UnknownAttrs []jsonAttr `json:"unknownAttrs,omitempty"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.JSONVersion > jsonVersion {
+		return fmt.Errorf("unsupported json version '%d'", v.JSONVersion)
+	}
+	*p = Podcast(v.podcastJSON)
+	p.UnknownAttrs = fromJSONAttrs(v.UnknownAttrs)
+	normalizeParsedPodcast(p)
+	return nil
+}
+
+type itemJSON Item
+
+func (i Item) MarshalJSON() ([]byte, error) {
+	fields := itemJSON(i)
+	var err error
+	fields.UnknownElements, err = withExtensionElements(fields.UnknownElements, fields.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		JSONVersion int `json:"jsonVersion"`
+		itemJSON
+		UnknownAttrs []jsonAttr `json:"unknownAttrs,omitempty"`
+	}{jsonVersion, fields, toJSONAttrs(fields.UnknownAttrs)})
+}
+
+func (i *Item) UnmarshalJSON(data []byte) error {
+	var v struct {
+		JSONVersion int `json:"jsonVersion"`
+		itemJSON
+		UnknownAttrs []jsonAttr `json:"unknownAttrs,omitempty"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.JSONVersion > jsonVersion {
+		return fmt.Errorf("unsupported json version '%d'", v.JSONVersion)
+	}
+	*i = Item(v.itemJSON)
+	i.UnknownAttrs = fromJSONAttrs(v.UnknownAttrs)
+	return nil
+}
+
+// jsonName is an xml.Name as it is stored in JSON, so that the stored format
+// doesn't depend on the field names of encoding/xml.
+type jsonName struct {
+	Space string `json:"space,omitempty"`
+	Local string `json:"local"`
+}
+
+// jsonAttr is an xml.Attr as it is stored in JSON, see jsonName.
+type jsonAttr struct {
+	Space string `json:"space,omitempty"`
+	Local string `json:"local"`
+	Value string `json:"value"`
+}
+
+func toJSONAttrs(attrs []xml.Attr) []jsonAttr {
+	if attrs == nil {
+		return nil
+	}
+	r := make([]jsonAttr, 0, len(attrs))
+	for _, a := range attrs {
+		r = append(r, jsonAttr{Space: a.Name.Space, Local: a.Name.Local, Value: a.Value})
+	}
+	return r
+}
+
+func fromJSONAttrs(attrs []jsonAttr) []xml.Attr {
+	if attrs == nil {
+		return nil
+	}
+	r := make([]xml.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		r = append(r, xml.Attr{Name: xml.Name{Space: a.Space, Local: a.Local}, Value: a.Value})
+	}
+	return r
+}
+
+type unknownElementJSON UnknownElement
+
+func (u UnknownElement) MarshalJSON() ([]byte, error) {
+	v := struct {
+		XMLName jsonName   `json:"xmlName"`
+		Attrs   []jsonAttr `json:"attrs,omitempty"`
+		After   *jsonName  `json:"after,omitempty"`
+		unknownElementJSON
+	}{XMLName: jsonName(u.XMLName), Attrs: toJSONAttrs(u.Attrs), unknownElementJSON: unknownElementJSON(u)}
+	if u.After != (xml.Name{}) {
+		after := jsonName(u.After)
+		v.After = &after
+	}
+	return json.Marshal(v)
+}
+
+func (u *UnknownElement) UnmarshalJSON(data []byte) error {
+	var v struct {
+		XMLName jsonName   `json:"xmlName"`
+		Attrs   []jsonAttr `json:"attrs,omitempty"`
+		After   *jsonName  `json:"after,omitempty"`
+		unknownElementJSON
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*u = UnknownElement(v.unknownElementJSON)
+	u.XMLName = xml.Name(v.XMLName)
+	u.Attrs = fromJSONAttrs(v.Attrs)
+	if v.After != nil {
+		u.After = xml.Name(*v.After)
+	}
+	return nil
+}
+
+// ParseJSON parses a podcast marshalled to JSON, decoding the elements of
+// registered extensions in the same way as ParseFeed.
+func (p *Parser) ParseJSON(r io.Reader) (*Podcast, error) {
+	var podcast Podcast
+	if err := json.NewDecoder(r).Decode(&podcast); err != nil {
+		return nil, err
+	}
+	if err := p.decodeExtensions(&podcast); err != nil {
+		return nil, err
+	}
+	return &podcast, nil
+}
+
+// withExtensionElements returns a copy of unknown with the elements of exts
+// added.
+func withExtensionElements(unknown []UnknownElement, exts []Extension) ([]UnknownElement, error) {
+	if len(exts) == 0 {
+		return unknown, nil
+	}
+	r := append([]UnknownElement{}, unknown...)
+	for _, ext := range exts {
+		els, err := extensionElements(ext)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, els...)
+	}
+	return r, nil
+}
+
+// withExtensionNamespaces returns a copy of namespaces with the namespaces
+// of exts added, if not already present.
+func withExtensionNamespaces(namespaces []Namespace, exts []Extension) []Namespace {
+	for _, ext := range exts {
+		if !hasNamespaceURI(namespaces, ext.Namespace) {
+			namespaces = append(namespaces[:len(namespaces):len(namespaces)], Namespace{Prefix: ext.Prefix, URI: ext.Namespace})
+		}
+	}
+	return namespaces
+}
+
+func (b Bool) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bool(b))
+}
+
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	v, err := unmarshalJSONBoolLike(data)
+	*b = Bool(v)
+	return err
+}
+
+func (b YesNo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bool(b))
+}
+
+func (b *YesNo) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	v, err := unmarshalJSONBoolLike(data)
+	*b = YesNo(v)
+	return err
+}
+
+// unmarshalJSONBoolLike unmarshals a JSON boolean, or a string of a bool-like
+// value such as "yes".
+func unmarshalJSONBoolLike(data []byte) (bool, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return unmarshalBoolLike([]byte(s)), nil
+	}
+	var v bool
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+func (k Keywords) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]string(k.normalize()))
+}
+
+func (k *Keywords) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return k.UnmarshalText([]byte(s))
+	}
+	var v []string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*k = Keywords(v).normalize()
+	return nil
+}
+
+func (c CountryCodes) MarshalJSON() ([]byte, error) {
+	if c == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal([]string(c.normalize()))
+}
+
+func (c *CountryCodes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return c.UnmarshalText([]byte(s))
+	}
+	var v []string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*c = CountryCodes(v).normalize()
+	return nil
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	s := time.Time(t).Format(time.RFC3339Nano)
+	// keep zone abbreviations which RFC 3339 can't hold, such as GMT, so
+	// that the time is written to a feed in the same way
+	if name, offset := time.Time(t).Zone(); name != "" && (name != "UTC" || offset != 0) {
+		s += " " + name
+	}
+	return json.Marshal(s)
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	value, zone, _ := strings.Cut(s, " ")
+	if tt, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		if zone != "" {
+			_, offset := tt.Zone()
+			tt = tt.In(time.FixedZone(zone, offset))
+		}
+		*t = Time(tt)
+		return nil
+	}
+	return t.UnmarshalText([]byte(s))
+}
+
+func (c PSCChapter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pscChapter{Start: NormalPlayTime(c.Start), Title: c.Title, Href: c.Href, Image: c.Image})
+}
+
+func (c *PSCChapter) UnmarshalJSON(data []byte) error {
+	var pc pscChapter
+	if err := json.Unmarshal(data, &pc); err != nil {
+		return err
+	}
+	*c = PSCChapter{Start: time.Duration(pc.Start), Title: pc.Title, Href: pc.Href, Image: pc.Image}
+	return nil
+}
+
+func (t NormalPlayTime) MarshalJSON() ([]byte, error) {
+	text, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+func (t *NormalPlayTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,252 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+func TestJSON_AllFieldsRoundTrip(t *testing.T) {
+	f, err := os.Open("testdata/test-feed-all.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	podcast, err := gopodcast.NewParser().ParseFeed(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(podcast)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTrue(t, bytes.HasPrefix(b, []byte(`{"jsonVersion":1,`)))
+
+	var loaded gopodcast.Podcast
+	if err := json.Unmarshal(b, &loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := &bytes.Buffer{}
+	if err := podcast.WriteFeedXML(exp); err != nil {
+		t.Fatal(err)
+	}
+	act := &bytes.Buffer{}
+	if err := loaded.WriteFeedXML(act); err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, exp.String(), act.String())
+	assertTrue(t, time.Time(*podcast.Items[0].PubDate).Equal(time.Time(*loaded.Items[0].PubDate)))
+	assertStr(t, podcast.PodcastFunding.URL, loaded.PodcastFunding.URL)
+}
+
+func TestJSON_KeyNames(t *testing.T) {
+	f, err := os.Open("testdata/test-feed-all.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	podcast, err := gopodcast.NewParser().ParseFeed(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	podcast.UnknownElements = []gopodcast.UnknownElement{{
+		XMLName: xml.Name{Space: "https://www.example.com/other", Local: "other"},
+		Attrs:   []xml.Attr{{Name: xml.Name{Local: "rel"}, Value: "self"}},
+		Text:    "Other",
+		After:   xml.Name{Local: "title"},
+	}}
+	podcast.UnknownAttrs = []xml.Attr{{Name: xml.Name{Space: "https://www.example.com/other", Local: "version"}, Value: "2"}}
+
+	b, err := json.Marshal(podcast)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(b)
+	for _, key := range []string{"jsonVersion", "title", "itunesAuthor", "itunesCategory", "podcastGUID", "atomLink", "items", "guid", "enclosure", "url", "pubDate", "unknownElements", "namespaces", "xmlName"} {
+		assertTrue(t, strings.Contains(s, `"`+key+`":`))
+	}
+	// xml names and attributes don't use the field names of encoding/xml
+	assertTrue(t, strings.Contains(s, `"xmlName":{"space":"https://www.example.com/other","local":"other"},"attrs":[{"local":"rel","value":"self"}],"after":{"local":"title"}`))
+	assertTrue(t, strings.Contains(s, `"unknownAttrs":[{"space":"https://www.example.com/other","local":"version","value":"2"}]`))
+
+	// all keys, including those of nested objects, are lower camel case
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatal(err)
+	}
+	var check func(v any)
+	check = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for k, child := range v {
+				if strings.ToLower(k[:1]) != k[:1] {
+					t.Fatalf("expected key '%s' to be lower camel case", k)
+				}
+				check(child)
+			}
+		case []any:
+			for _, child := range v {
+				check(child)
+			}
+		}
+	}
+	check(v)
+
+	// and are read back
+	var loaded gopodcast.Podcast
+	if err := json.Unmarshal(b, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	el := loaded.UnknownElements[0]
+	assertStr(t, "https://www.example.com/other", el.XMLName.Space)
+	assertStr(t, "other", el.XMLName.Local)
+	assertStr(t, "rel", el.Attrs[0].Name.Local)
+	assertStr(t, "self", el.Attrs[0].Value)
+	assertStr(t, "title", el.After.Local)
+	assertStr(t, "https://www.example.com/other", loaded.UnknownAttrs[0].Name.Space)
+	assertStr(t, "version", loaded.UnknownAttrs[0].Name.Local)
+	assertStr(t, "2", loaded.UnknownAttrs[0].Value)
+}
+
+func TestJSON_TopPodcastsRoundTrip(t *testing.T) {
+	for _, file := range sampleTopPodcasts {
+		t.Run(file, func(t *testing.T) {
+			f, err := os.Open(path.Join("testdata/top-podcasts", file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			podcast, err := gopodcast.NewParser().ParseFeed(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := json.Marshal(podcast)
+			if err != nil {
+				t.Fatal(err)
+			}
+			loaded, err := gopodcast.NewParser().ParseJSON(bytes.NewReader(b))
+			if err != nil {
+				t.Fatal(err)
+			}
+			reloaded, err := json.Marshal(loaded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertStr(t, string(b), string(reloaded))
+			checkRequiredFeedValuesPresent(t, loaded)
+		})
+	}
+}
+
+func TestJSON_Types(t *testing.T) {
+	yes := gopodcast.YesNo(true)
+	item := gopodcast.Item{
+		Title:          "Test episode 1",
+		PubDate:        timeFromStr("2024-12-20T10:00:00"),
+		ITunesExplicit: new(gopodcast.Bool),
+		ITunesBlock:    &yes,
+		ITunesKeywords: gopodcast.Keywords{"a", "b"},
+		PSCChapters: &gopodcast.PSCChapters{
+			Chapters: []gopodcast.PSCChapter{{Start: 62500 * time.Millisecond}},
+		},
+	}
+
+	b, err := json.Marshal(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(b)
+	assertTrue(t, strings.HasPrefix(s, `{"jsonVersion":1,`))
+	assertTrue(t, strings.Contains(s, `"pubDate":"2024-12-20T10:00:00Z"`))
+	assertTrue(t, strings.Contains(s, `"itunesExplicit":false`))
+	assertTrue(t, strings.Contains(s, `"itunesBlock":true`))
+	assertTrue(t, strings.Contains(s, `"itunesKeywords":["a","b"]`))
+	assertTrue(t, strings.Contains(s, `"start":"00:01:02.500"`))
+
+	var loaded gopodcast.Item
+	if err := json.Unmarshal(b, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, "2024-12-20T10:00:00Z", time.Time(*loaded.PubDate).Format(time.RFC3339))
+	assertBool(t, false, bool(*loaded.ITunesExplicit))
+	assertBool(t, true, bool(*loaded.ITunesBlock))
+	assertStr(t, "a,b", strings.Join(loaded.ITunesKeywords, ","))
+	assertInt(t, 62500, int(loaded.PSCChapters.Chapters[0].Start.Milliseconds()))
+}
+
+func TestJSON_CountryCodes(t *testing.T) {
+	podcast := gopodcast.Podcast{SpotifyCountryOfOrigin: gopodcast.CountryCodes{"GB", "us"}}
+	b, err := json.Marshal(podcast)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTrue(t, strings.Contains(string(b), `"spotifyCountryOfOrigin":["gb","us"]`))
+
+	var loaded gopodcast.Podcast
+	if err := json.Unmarshal(b, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, "gb,us", strings.Join(loaded.SpotifyCountryOfOrigin, ","))
+
+	// invalid codes are kept
+	if err := json.Unmarshal([]byte(`{"spotifyCountryOfOrigin":["gb","XX"]}`), &loaded); err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, "gb,xx", strings.Join(loaded.SpotifyCountryOfOrigin, ","))
+	b, err = json.Marshal(loaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTrue(t, strings.Contains(string(b), `"spotifyCountryOfOrigin":["gb","xx"]`))
+}
+
+func TestJSON_Extensions(t *testing.T) {
+	parser := gopodcast.NewParser()
+	if err := parser.RegisterExtension(houseNS, "house", houseTags{}); err != nil {
+		t.Fatal(err)
+	}
+
+	podcast, err := parser.ParseFeed(strings.NewReader(houseFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	podcast.Items[1].SetExtension("https://www.example.com/other", "other", &houseTags{Rating: "U"})
+
+	b, err := json.Marshal(podcast)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := parser.ParseJSON(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, "PG", loaded.Extension(houseNS).(*houseTags).Rating)
+	assertStr(t, "Sponsor 1,Sponsor 2", strings.Join(loaded.Items[0].Extension(houseNS).(*houseTags).Sponsor, ","))
+	assertInt(t, 1, len(loaded.Items[0].UnknownElements))
+
+	// unregistered extensions are kept as unknown elements, with their prefix
+	assertInt(t, 1, len(loaded.Items[1].UnknownElements))
+	buf := &bytes.Buffer{}
+	if err := loaded.WriteFeedXML(buf); err != nil {
+		t.Fatal(err)
+	}
+	assertTrue(t, strings.Contains(buf.String(), `<other:rating>U</other:rating>`))
+}
+
+func TestJSON_UnsupportedVersion(t *testing.T) {
+	var podcast gopodcast.Podcast
+	err := json.Unmarshal([]byte(`{"jsonVersion":2,"title":"Test podcast 1"}`), &podcast)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	assertStr(t, "unsupported json version '2'", err.Error())
+}
@@ -0,0 +1,392 @@
+package gopodcast
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+const (
+	jsonFeedVersion      = "https://jsonfeed.org/version/1.1"
+	jsonFeedPodcastAbout = "https://github.com/webbgeorge/gopodcast"
+)
+
+// jsonFeed is a JSON Feed 1.1 feed, see https://www.jsonfeed.org/version/1.1/.
+// iTunes and Podcasting 2.0 values are carried in the _podcast extension.
+type jsonFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url,omitempty"`
+	FeedURL     string           `json:"feed_url,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Icon        string           `json:"icon,omitempty"`
+	Authors     []jsonFeedAuthor `json:"authors,omitempty"`
+	Language    string           `json:"language,omitempty"`
+	Expired     *bool            `json:"expired,omitempty"`
+	Items       []jsonFeedItem   `json:"items"`
+	Podcast     *jsonFeedPodcast `json:"_podcast,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type jsonFeedItem struct {
+	ID            string               `json:"id"`
+	URL           string               `json:"url,omitempty"`
+	Title         string               `json:"title,omitempty"`
+	ContentHTML   string               `json:"content_html,omitempty"`
+	ContentText   string               `json:"content_text,omitempty"`
+	Image         string               `json:"image,omitempty"`
+	DatePublished string               `json:"date_published,omitempty"`
+	Authors       []jsonFeedAuthor     `json:"authors,omitempty"`
+	Tags          []string             `json:"tags,omitempty"`
+	Attachments   []jsonFeedAttachment `json:"attachments,omitempty"`
+	Podcast       *jsonFeedItemPodcast `json:"_podcast,omitempty"`
+}
+
+type jsonFeedAttachment struct {
+	URL               string  `json:"url"`
+	MimeType          string  `json:"mime_type"`
+	SizeInBytes       int64   `json:"size_in_bytes,omitempty"`
+	DurationInSeconds float64 `json:"duration_in_seconds,omitempty"`
+}
+
+// jsonFeedPodcast is the _podcast extension of a feed
+type jsonFeedPodcast struct {
+	About      string             `json:"about"`
+	GUID       string             `json:"guid,omitempty"`
+	Locked     *bool              `json:"locked,omitempty"`
+	Explicit   bool               `json:"explicit"`
+	Image      string             `json:"image,omitempty"`
+	Categories []jsonFeedCategory `json:"categories,omitempty"`
+	Author     string             `json:"author,omitempty"`
+	Owner      *jsonFeedOwner     `json:"owner,omitempty"`
+	Type       string             `json:"type,omitempty"`
+	Summary    string             `json:"summary,omitempty"`
+	Subtitle   string             `json:"subtitle,omitempty"`
+	NewFeedURL string             `json:"new_feed_url,omitempty"`
+	Keywords   []string           `json:"keywords,omitempty"`
+	Copyright  string             `json:"copyright,omitempty"`
+	Fundings   []jsonFeedFunding  `json:"funding,omitempty"`
+}
+
+type jsonFeedCategory struct {
+	Text        string            `json:"text"`
+	SubCategory *jsonFeedCategory `json:"subcategory,omitempty"`
+}
+
+type jsonFeedOwner struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+type jsonFeedFunding struct {
+	URL  string `json:"url"`
+	Text string `json:"text,omitempty"`
+}
+
+// jsonFeedItemPodcast is the _podcast extension of an item
+type jsonFeedItemPodcast struct {
+	GUIDIsPermaLink *bool                `json:"guid_is_permalink,omitempty"`
+	Title           string               `json:"title,omitempty"`
+	Author          string               `json:"author,omitempty"`
+	Duration        string               `json:"duration,omitempty"`
+	Explicit        *bool                `json:"explicit,omitempty"`
+	Episode         string               `json:"episode,omitempty"`
+	Season          string               `json:"season,omitempty"`
+	EpisodeType     string               `json:"episode_type,omitempty"`
+	Block           *bool                `json:"block,omitempty"`
+	Keywords        []string             `json:"keywords,omitempty"`
+	Order           *int                 `json:"order,omitempty"`
+	Transcripts     []jsonFeedTranscript `json:"transcripts,omitempty"`
+	Fundings        []jsonFeedFunding    `json:"funding,omitempty"`
+}
+
+type jsonFeedTranscript struct {
+	URL      string `json:"url"`
+	Type     string `json:"type"`
+	Rel      string `json:"rel,omitempty"`
+	Language string `json:"language,omitempty"`
+}
+
+// WriteJSONFeed writes the podcast as a JSON Feed 1.1 feed. Enclosures are
+// written as attachments, and iTunes and Podcasting 2.0 values are written in
+// a _podcast extension object, which ParseJSONFeed reads back.
+func (p *Podcast) WriteJSONFeed(w io.Writer) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	e.SetEscapeHTML(false)
+	return e.Encode(p.withLegacyFields().jsonFeed())
+}
+
+// ParseJSONFeed parses a JSON Feed 1.1 feed, such as one written by
+// WriteJSONFeed.
+func (p *Parser) ParseJSONFeed(r io.Reader) (*Podcast, error) {
+	var feed jsonFeed
+	if err := json.NewDecoder(r).Decode(&feed); err != nil {
+		return nil, err
+	}
+	if feed.Version != jsonFeedVersion && feed.Version != "https://jsonfeed.org/version/1" {
+		return nil, fmt.Errorf("unsupported json feed version '%s'", feed.Version)
+	}
+	return feed.podcast()
+}
+
+func (p *Podcast) jsonFeed() *jsonFeed {
+	f := &jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       p.Title,
+		HomePageURL: p.Link,
+		FeedURL:     p.AtomLink.Href,
+		Description: p.Description.Text,
+		Icon:        p.ITunesImage.Href,
+		Language:    p.Language,
+		Items:       []jsonFeedItem{},
+		Podcast: &jsonFeedPodcast{
+			About:      jsonFeedPodcastAbout,
+			GUID:       p.PodcastGUID,
+			Locked:     (*bool)(p.PodcastLocked),
+			Explicit:   bool(p.ITunesExplicit),
+			Image:      p.ITunesImage.Href,
+			Author:     p.ITunesAuthor,
+			Type:       p.ITunesType,
+			Summary:    p.ITunesSummary,
+			Subtitle:   p.ITunesSubtitle,
+			NewFeedURL: p.ITunesNewFeedURL,
+			Keywords:   p.ITunesKeywords,
+			Copyright:  p.Copyright,
+			Fundings:   jsonFeedFundings(p.PodcastFundings),
+		},
+	}
+	if p.ITunesComplete != nil {
+		expired := bool(*p.ITunesComplete)
+		f.Expired = &expired
+	}
+	if p.ITunesAuthor != "" {
+		f.Authors = []jsonFeedAuthor{{Name: p.ITunesAuthor}}
+	}
+	for _, c := range p.ITunesCategory {
+		f.Podcast.Categories = append(f.Podcast.Categories, *jsonFeedCategoryFrom(&c))
+	}
+	if p.ITunesOwner != nil {
+		f.Podcast.Owner = &jsonFeedOwner{Name: p.ITunesOwner.Name, Email: p.ITunesOwner.Email}
+	}
+	for _, item := range p.Items {
+		f.Items = append(f.Items, item.jsonFeedItem())
+	}
+	return f
+}
+
+func (i *Item) jsonFeedItem() jsonFeedItem {
+	fi := jsonFeedItem{
+		ID:    firstNonEmpty(i.GUID.Text, i.Link, i.Enclosure.URL),
+		URL:   i.Link,
+		Title: i.Title,
+		Podcast: &jsonFeedItemPodcast{
+			GUIDIsPermaLink: (*bool)(i.GUID.IsPermaLink),
+			Title:           i.ITunesTitle,
+			Author:          i.ITunesAuthor,
+			Duration:        i.ITunesDuration,
+			Explicit:        (*bool)(i.ITunesExplicit),
+			Episode:         i.ITunesEpisode,
+			Season:          i.ITunesSeason,
+			EpisodeType:     i.ITunesEpisodeType,
+			Block:           (*bool)(i.ITunesBlock),
+			Keywords:        i.ITunesKeywords,
+			Order:           intValue(i.ITunesOrder),
+			Fundings:        jsonFeedFundings(i.PodcastFundings),
+		},
+	}
+	if i.Description != nil {
+		fi.ContentHTML = i.Description.Text
+	}
+	if i.ITunesImage != nil {
+		fi.Image = i.ITunesImage.Href
+	}
+	if date := i.Date(); date != nil {
+		fi.DatePublished = date.marshalISO8601()
+	}
+	if creator := i.Creator(); creator != "" {
+		fi.Authors = []jsonFeedAuthor{{Name: creator}}
+	}
+	for _, c := range i.Category {
+		fi.Tags = append(fi.Tags, c.Text)
+	}
+	if i.Enclosure.URL != "" {
+		a := jsonFeedAttachment{
+			URL:         i.Enclosure.URL,
+			MimeType:    i.Enclosure.Type,
+			SizeInBytes: i.Enclosure.Length,
+		}
+		var duration NormalPlayTime
+		if err := duration.UnmarshalText([]byte(i.ITunesDuration)); err == nil {
+			a.DurationInSeconds = time.Duration(duration).Seconds()
+		}
+		fi.Attachments = append(fi.Attachments, a)
+	}
+	for _, t := range i.PodcastTranscript {
+		fi.Podcast.Transcripts = append(fi.Podcast.Transcripts, jsonFeedTranscript(t))
+	}
+	if reflect.ValueOf(*fi.Podcast).IsZero() {
+		fi.Podcast = nil
+	}
+	return fi
+}
+
+func (f *jsonFeed) podcast() (*Podcast, error) {
+	pc := &Podcast{
+		Title:       f.Title,
+		Link:        f.HomePageURL,
+		Description: Description{Text: f.Description},
+		Language:    f.Language,
+		ITunesImage: ITunesImage{Href: f.Icon},
+	}
+	if f.FeedURL != "" {
+		pc.AtomLink = AtomLink{Href: f.FeedURL, Rel: "self", Type: "application/feed+json"}
+	}
+	if f.Expired != nil {
+		complete := YesNo(*f.Expired)
+		pc.ITunesComplete = &complete
+	}
+	if len(f.Authors) > 0 {
+		pc.ITunesAuthor = f.Authors[0].Name
+	}
+
+	if ext := f.Podcast; ext != nil {
+		pc.PodcastGUID = ext.GUID
+		pc.PodcastLocked = (*YesNo)(ext.Locked)
+		pc.ITunesExplicit = Bool(ext.Explicit)
+		if ext.Image != "" {
+			pc.ITunesImage.Href = ext.Image
+		}
+		for _, c := range ext.Categories {
+			pc.ITunesCategory = append(pc.ITunesCategory, *c.iTunesCategory())
+		}
+		if ext.Author != "" {
+			pc.ITunesAuthor = ext.Author
+		}
+		if ext.Owner != nil {
+			pc.ITunesOwner = &ITunesOwner{Name: ext.Owner.Name, Email: ext.Owner.Email}
+		}
+		pc.ITunesType = ext.Type
+		pc.ITunesSummary = ext.Summary
+		pc.ITunesSubtitle = ext.Subtitle
+		pc.ITunesNewFeedURL = ext.NewFeedURL
+		pc.ITunesKeywords = ext.Keywords
+		pc.Copyright = ext.Copyright
+		pc.PodcastFundings = podcastFundings(ext.Fundings)
+	}
+
+	for _, fi := range f.Items {
+		item, err := fi.item()
+		if err != nil {
+			return nil, err
+		}
+		pc.Items = append(pc.Items, item)
+	}
+	normalizeParsedPodcast(pc)
+	return pc, nil
+}
+
+func (fi *jsonFeedItem) item() (*Item, error) {
+	item := &Item{
+		Title: fi.Title,
+		GUID:  ItemGUID{Text: fi.ID},
+		Link:  fi.URL,
+	}
+	if desc := firstNonEmpty(fi.ContentHTML, fi.ContentText); desc != "" {
+		item.Description = &Description{Text: desc}
+	}
+	if fi.Image != "" {
+		item.ITunesImage = &ITunesImage{Href: fi.Image}
+	}
+	if fi.DatePublished != "" {
+		var t Time
+		if err := t.UnmarshalText([]byte(fi.DatePublished)); err != nil {
+			return nil, err
+		}
+		item.PubDate = &t
+	}
+	if len(fi.Authors) > 0 {
+		item.ITunesAuthor = fi.Authors[0].Name
+	}
+	for _, tag := range fi.Tags {
+		item.Category = append(item.Category, Category{Text: tag})
+	}
+	if len(fi.Attachments) > 0 {
+		a := fi.Attachments[0]
+		item.Enclosure = Enclosure{URL: a.URL, Type: a.MimeType, Length: a.SizeInBytes}
+		if a.DurationInSeconds > 0 {
+			item.ITunesDuration = strconv.Itoa(int(a.DurationInSeconds))
+		}
+	}
+
+	if ext := fi.Podcast; ext != nil {
+		item.GUID.IsPermaLink = (*Bool)(ext.GUIDIsPermaLink)
+		item.ITunesTitle = ext.Title
+		if ext.Author != "" {
+			item.ITunesAuthor = ext.Author
+		}
+		if ext.Duration != "" {
+			item.ITunesDuration = ext.Duration
+		}
+		item.ITunesExplicit = (*Bool)(ext.Explicit)
+		item.ITunesEpisode = ext.Episode
+		item.ITunesSeason = ext.Season
+		item.ITunesEpisodeType = ext.EpisodeType
+		item.ITunesBlock = (*YesNo)(ext.Block)
+		item.ITunesKeywords = ext.Keywords
+		if ext.Order != nil {
+			item.ITunesOrder = NewInt(*ext.Order)
+		}
+		for _, t := range ext.Transcripts {
+			item.PodcastTranscript = append(item.PodcastTranscript, PodcastTranscript(t))
+		}
+		item.PodcastFundings = podcastFundings(ext.Fundings)
+	}
+	return item, nil
+}
+
+func jsonFeedCategoryFrom(c *ITunesCategory) *jsonFeedCategory {
+	if c == nil {
+		return nil
+	}
+	return &jsonFeedCategory{Text: c.Text, SubCategory: jsonFeedCategoryFrom(c.SubCategory)}
+}
+
+func (c *jsonFeedCategory) iTunesCategory() *ITunesCategory {
+	if c == nil {
+		return nil
+	}
+	return &ITunesCategory{Text: c.Text, SubCategory: c.SubCategory.iTunesCategory()}
+}
+
+func jsonFeedFundings(fundings []PodcastFunding) []jsonFeedFunding {
+	var r []jsonFeedFunding
+	for _, f := range fundings {
+		r = append(r, jsonFeedFunding{URL: f.URL, Text: f.Text})
+	}
+	return r
+}
+
+func podcastFundings(fundings []jsonFeedFunding) []PodcastFunding {
+	var r []PodcastFunding
+	for _, f := range fundings {
+		r = append(r, PodcastFunding{URL: f.URL, Text: f.Text})
+	}
+	return r
+}
+
+// intValue returns a pointer to the value of i, or nil if it isn't valid.
+func intValue(i Int) *int {
+	n, ok := i.Value()
+	if !ok {
+		return nil
+	}
+	return &n
+}
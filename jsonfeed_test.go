@@ -0,0 +1,160 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+func TestWriteJSONFeed(t *testing.T) {
+	complete := gopodcast.YesNo(true)
+	explicit := gopodcast.Bool(false)
+	podcast := &gopodcast.Podcast{
+		AtomLink:       gopodcast.AtomLink{Href: "https://www.example.com/feed.json", Rel: "self", Type: "application/feed+json"},
+		Title:          "Test podcast 1",
+		Description:    gopodcast.Description{Text: "<p>Test description</p>"},
+		Link:           "https://www.example.com",
+		Language:       "en-gb",
+		ITunesCategory: []gopodcast.ITunesCategory{{Text: "Comedy", SubCategory: &gopodcast.ITunesCategory{Text: "Improv"}}},
+		ITunesExplicit: true,
+		ITunesImage:    gopodcast.ITunesImage{Href: "https://www.example.com/image.jpg"},
+		ITunesAuthor:   "Test author",
+		ITunesOwner:    &gopodcast.ITunesOwner{Name: "Test owner", Email: "owner@example.com"},
+		ITunesComplete: &complete,
+		ITunesType:     "episodic",
+		PodcastGUID:    "917393e3-1b1e-5cef-ace4-edaa54e1f810",
+		PodcastFundings: []gopodcast.PodcastFunding{
+			{URL: "https://www.example.com/donate", Text: "Support the show"},
+		},
+		Copyright: "Test copyright",
+		Items: []*gopodcast.Item{
+			{
+				Title: "Test episode 1",
+				Enclosure: gopodcast.Enclosure{
+					URL:    "https://www.example.com/episode-1.mp3",
+					Type:   "audio/mpeg",
+					Length: 1001,
+				},
+				GUID:              gopodcast.ItemGUID{Text: "urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a"},
+				Link:              "https://www.example.com/episode-1",
+				PubDate:           timeFromStr("2024-12-20T10:00:00"),
+				Description:       &gopodcast.Description{Text: "Test episode summary"},
+				Category:          []gopodcast.Category{{Text: "Comedy"}},
+				ITunesDuration:    "01:02:03",
+				ITunesAuthor:      "Episode author",
+				ITunesExplicit:    &explicit,
+				ITunesEpisode:     "1",
+				ITunesEpisodeType: "full",
+				PodcastTranscript: []gopodcast.PodcastTranscript{
+					{URL: "https://www.example.com/episode-1.vtt", Type: "text/vtt", Rel: "captions"},
+				},
+			},
+			{
+				Title: "Test episode 2",
+				Enclosure: gopodcast.Enclosure{
+					URL:  "https://www.example.com/episode-2.mp3",
+					Type: "audio/mpeg",
+				},
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	err := podcast.WriteJSONFeed(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp, err := os.ReadFile("testdata/test-feed-write-json.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, strings.TrimSpace(string(exp)), strings.TrimSpace(buf.String()))
+
+	// and can be parsed again
+	written, err := gopodcast.NewParser().ParseJSONFeed(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, "Test podcast 1", written.Title)
+	assertStr(t, "<p>Test description</p>", written.Description.Text)
+	assertStr(t, "https://www.example.com", written.Link)
+	assertStr(t, "https://www.example.com/feed.json", written.AtomLink.Href)
+	assertStr(t, "en-gb", written.Language)
+	assertStr(t, "https://www.example.com/image.jpg", written.ITunesImage.Href)
+	assertStr(t, "Comedy", written.ITunesCategory[0].Text)
+	assertStr(t, "Improv", written.ITunesCategory[0].SubCategory.Text)
+	assertBool(t, true, bool(written.ITunesExplicit))
+	assertStr(t, "Test author", written.ITunesAuthor)
+	assertStr(t, "owner@example.com", written.ITunesOwner.Email)
+	assertBool(t, true, bool(*written.ITunesComplete))
+	assertStr(t, "episodic", written.ITunesType)
+	assertStr(t, "917393e3-1b1e-5cef-ace4-edaa54e1f810", written.PodcastGUID)
+	assertStr(t, "Support the show", written.PodcastFunding.Text)
+	assertStr(t, "Test copyright", written.Copyright)
+
+	assertInt(t, 2, len(written.Items))
+	item := written.Items[0]
+	assertStr(t, "Test episode 1", item.Title)
+	assertStr(t, "urn:uuid:1225c695-cfb8-4ebb-aaaa-80da344efa6a", item.GUID.Text)
+	assertStr(t, "https://www.example.com/episode-1", item.Link)
+	assertStr(t, "https://www.example.com/episode-1.mp3", item.Enclosure.URL)
+	assertStr(t, "audio/mpeg", item.Enclosure.Type)
+	assertInt(t, 1001, int(item.Enclosure.Length))
+	assertStr(t, "2024-12-20T10:00:00Z", time.Time(*item.PubDate).Format(time.RFC3339))
+	assertStr(t, "Test episode summary", item.Description.Text)
+	assertStr(t, "Comedy", item.Category[0].Text)
+	assertStr(t, "01:02:03", item.ITunesDuration)
+	assertStr(t, "Episode author", item.ITunesAuthor)
+	assertBool(t, false, bool(*item.ITunesExplicit))
+	assertStr(t, "1", item.ITunesEpisode)
+	assertStr(t, "full", item.ITunesEpisodeType)
+	assertStr(t, "captions", item.PodcastTranscript[0].Rel)
+	assertStr(t, "https://www.example.com/episode-2.mp3", written.Items[1].GUID.Text)
+}
+
+func TestParseJSONFeed(t *testing.T) {
+	feed := `{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "Test podcast",
+  "home_page_url": "https://www.example.com",
+  "authors": [{"name": "Test author"}],
+  "items": [
+    {
+      "id": "1",
+      "content_text": "Test episode summary",
+      "date_published": "2024-12-20T10:00:00+01:00",
+      "attachments": [
+        {"url": "https://www.example.com/episode-1.mp3", "mime_type": "audio/mpeg", "duration_in_seconds": 600}
+      ]
+    }
+  ]
+}`
+
+	podcast, err := gopodcast.NewParser().ParseJSONFeed(strings.NewReader(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "Test podcast", podcast.Title)
+	assertStr(t, "https://www.example.com", podcast.Link)
+	assertStr(t, "Test author", podcast.ITunesAuthor)
+	assertInt(t, 1, len(podcast.Items))
+	assertStr(t, "1", podcast.Items[0].GUID.Text)
+	assertStr(t, "Test episode summary", podcast.Items[0].Description.Text)
+	assertStr(t, "2024-12-20T09:00:00Z", time.Time(*podcast.Items[0].PubDate).UTC().Format(time.RFC3339))
+	assertStr(t, "https://www.example.com/episode-1.mp3", podcast.Items[0].Enclosure.URL)
+	assertStr(t, "600", podcast.Items[0].ITunesDuration)
+}
+
+func TestParseJSONFeed_InvalidVersion(t *testing.T) {
+	_, err := gopodcast.NewParser().ParseJSONFeed(strings.NewReader(`{"version": "https://example.com"}`))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	assertStr(t, "unsupported json feed version 'https://example.com'", err.Error())
+}
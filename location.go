@@ -0,0 +1,120 @@
+package gopodcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const defaultMaxNewFeedURLHops = 5
+
+// FeedLocation describes where a feed was found when parsed from a URL.
+type FeedLocation struct {
+	// URL is the canonical URL of the feed, which should be used in place of
+	// the requested URL from now on.
+	URL string
+
+	// Moves lists each permanent move of the feed which was followed to get
+	// to URL, in order.
+	Moves []FeedMove
+}
+
+// FeedMove is a permanent move of a feed from one URL to another.
+type FeedMove struct {
+	From   string
+	To     string
+	Reason FeedMoveReason
+}
+
+type FeedMoveReason string
+
+const (
+	// FeedMovePermanentRedirect is an HTTP 301 or 308 redirect
+	FeedMovePermanentRedirect FeedMoveReason = "permanent-redirect"
+	// FeedMoveNewFeedURL is an itunes:new-feed-url link
+	FeedMoveNewFeedURL FeedMoveReason = "new-feed-url"
+)
+
+func (l *FeedLocation) move(to string, reason FeedMoveReason) {
+	l.Moves = append(l.Moves, FeedMove{From: l.URL, To: to, Reason: reason})
+	l.URL = to
+}
+
+// ParseFeedFromURLWithLocation parses the feed at url in the same way as
+// ParseFeedFromURL, additionally returning the feed's canonical location.
+//
+// Permanent HTTP redirects are recorded as moves, as long as they are not
+// preceded by a temporary redirect. When FollowNewFeedURL is set,
+// itunes:new-feed-url links are followed and recorded as moves too, and an
+// error is returned if they loop or exceed MaxNewFeedURLHops.
+func (p *Parser) ParseFeedFromURLWithLocation(ctx context.Context, url string) (*Podcast, *FeedLocation, error) {
+	loc := &FeedLocation{URL: url}
+	visited := make(map[string]bool)
+	var fetched string
+	visit := func(u string) {
+		visited[u] = true
+		fetched = u
+	}
+
+	for hops := 0; ; hops++ {
+		visit(loc.URL)
+		pc, err := p.parseFeedFromURL(ctx, p.redirectRecordingClient(loc, visit), loc.URL, url)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !p.FollowNewFeedURL || pc == nil {
+			return pc, loc, nil
+		}
+		next := strings.TrimSpace(pc.ITunesNewFeedURL)
+		if next == "" || next == loc.URL || next == fetched {
+			return pc, loc, nil
+		}
+		if visited[next] {
+			return nil, nil, fmt.Errorf("itunes:new-feed-url loop detected at '%s'", next)
+		}
+		if hops >= p.maxNewFeedURLHops() {
+			return nil, nil, fmt.Errorf("too many itunes:new-feed-url hops, stopped at '%s'", loc.URL)
+		}
+		loc.move(next, FeedMoveNewFeedURL)
+	}
+}
+
+func (p *Parser) maxNewFeedURLHops() int {
+	if p.MaxNewFeedURLHops <= 0 {
+		return defaultMaxNewFeedURLHops
+	}
+	return p.MaxNewFeedURLHops
+}
+
+// redirectRecordingClient returns a copy of the parser's http client which
+// records permanent redirects in loc, and calls visit with the URL of every
+// redirect followed, permanent or not.
+func (p *Parser) redirectRecordingClient(loc *FeedLocation, visit func(string)) *http.Client {
+	client := *p.HTTPClient
+	checkRedirect := client.CheckRedirect
+	permanent := true
+
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if checkRedirect != nil {
+			if err := checkRedirect(req, via); err != nil {
+				return err
+			}
+		} else if len(via) >= 10 {
+			// same as the http package's default policy
+			return errors.New("stopped after 10 redirects")
+		}
+
+		visit(req.URL.String())
+		code := req.Response.StatusCode
+		permanent = permanent && (code == http.StatusMovedPermanently || code == http.StatusPermanentRedirect)
+		if permanent {
+			loc.move(req.URL.String(), FeedMovePermanentRedirect)
+		}
+		return nil
+	}
+
+	return &client
+}
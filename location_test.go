@@ -0,0 +1,224 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+func TestParseFeedFromURLWithLocation_RecordsPermanentRedirects(t *testing.T) {
+	parser := gopodcast.NewParser()
+	parser.HTTPClient = newRouteClient(map[string]testRoute{
+		"http://old.example.com/feed":   {status: 301, location: "http://new.example.com/feed"},
+		"http://new.example.com/feed":   {status: 308, location: "http://newer.example.com/feed"},
+		"http://newer.example.com/feed": {status: 302, location: "http://cdn.example.com/feed"},
+		"http://cdn.example.com/feed":   {status: 200, body: testMovedFeed("Podcast", "")},
+	})
+
+	podcast, loc, err := parser.ParseFeedFromURLWithLocation(context.Background(), "http://old.example.com/feed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "Podcast", podcast.Title)
+	assertStr(t, "http://newer.example.com/feed", loc.URL)
+	assertInt(t, 2, len(loc.Moves))
+	assertStr(t, "http://old.example.com/feed", loc.Moves[0].From)
+	assertStr(t, "http://new.example.com/feed", loc.Moves[0].To)
+	assertStr(t, string(gopodcast.FeedMovePermanentRedirect), string(loc.Moves[0].Reason))
+	assertStr(t, "http://new.example.com/feed", loc.Moves[1].From)
+	assertStr(t, "http://newer.example.com/feed", loc.Moves[1].To)
+}
+
+func TestParseFeedFromURLWithLocation_FollowsNewFeedURL(t *testing.T) {
+	parser := gopodcast.NewParser()
+	parser.FollowNewFeedURL = true
+	parser.HTTPClient = newRouteClient(map[string]testRoute{
+		"http://a.example.com/feed": {status: 200, body: testMovedFeed("A", "http://b.example.com/feed")},
+		"http://b.example.com/feed": {status: 301, location: "http://c.example.com/feed"},
+		"http://c.example.com/feed": {status: 200, body: testMovedFeed("C", "http://c.example.com/feed")},
+	})
+
+	podcast, loc, err := parser.ParseFeedFromURLWithLocation(context.Background(), "http://a.example.com/feed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "C", podcast.Title)
+	assertStr(t, "http://c.example.com/feed", loc.URL)
+	assertInt(t, 2, len(loc.Moves))
+	assertStr(t, string(gopodcast.FeedMoveNewFeedURL), string(loc.Moves[0].Reason))
+	assertStr(t, "http://b.example.com/feed", loc.Moves[0].To)
+	assertStr(t, string(gopodcast.FeedMovePermanentRedirect), string(loc.Moves[1].Reason))
+	assertStr(t, "http://c.example.com/feed", loc.Moves[1].To)
+}
+
+func TestParseFeedFromURLWithLocation_NewFeedURLNotFollowedByDefault(t *testing.T) {
+	parser := gopodcast.NewParser()
+	parser.HTTPClient = newRouteClient(map[string]testRoute{
+		"http://a.example.com/feed": {status: 200, body: testMovedFeed("A", "http://b.example.com/feed")},
+	})
+
+	podcast, loc, err := parser.ParseFeedFromURLWithLocation(context.Background(), "http://a.example.com/feed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "A", podcast.Title)
+	assertStr(t, "http://b.example.com/feed", podcast.ITunesNewFeedURL)
+	assertStr(t, "http://a.example.com/feed", loc.URL)
+	assertInt(t, 0, len(loc.Moves))
+}
+
+func TestParseFeedFromURL_NewFeedURLLoop(t *testing.T) {
+	parser := gopodcast.NewParser()
+	parser.FollowNewFeedURL = true
+	parser.HTTPClient = newRouteClient(map[string]testRoute{
+		"http://a.example.com/feed": {status: 200, body: testMovedFeed("A", "http://b.example.com/feed")},
+		"http://b.example.com/feed": {status: 200, body: testMovedFeed("B", "http://a.example.com/feed")},
+	})
+
+	podcast, err := parser.ParseFeedFromURL(context.Background(), "http://a.example.com/feed")
+
+	assertNil(t, podcast)
+	assertStr(t, "itunes:new-feed-url loop detected at 'http://a.example.com/feed'", err.Error())
+}
+
+func TestParseFeedFromURL_NewFeedURLRedirectLoop(t *testing.T) {
+	parser := gopodcast.NewParser()
+	parser.FollowNewFeedURL = true
+	parser.HTTPClient = newRouteClient(map[string]testRoute{
+		"http://a.example.com/feed": {status: 200, body: testMovedFeed("A", "http://b.example.com/feed")},
+		"http://b.example.com/feed": {status: 302, location: "http://c.example.com/feed"},
+		"http://c.example.com/feed": {status: 200, body: testMovedFeed("C", "http://d.example.com/feed")},
+		"http://d.example.com/feed": {status: 200, body: testMovedFeed("D", "http://c.example.com/feed")},
+	})
+
+	podcast, err := parser.ParseFeedFromURL(context.Background(), "http://a.example.com/feed")
+
+	// c was visited through the temporary redirect from b
+	assertNil(t, podcast)
+	assertStr(t, "itunes:new-feed-url loop detected at 'http://c.example.com/feed'", err.Error())
+}
+
+func TestParseFeedFromURLWithLocation_NewFeedURLToRedirectTarget(t *testing.T) {
+	parser := gopodcast.NewParser()
+	parser.FollowNewFeedURL = true
+	parser.HTTPClient = newRouteClient(map[string]testRoute{
+		"http://a.example.com/feed": {status: 302, location: "http://b.example.com/feed"},
+		"http://b.example.com/feed": {status: 200, body: testMovedFeed("B", "http://b.example.com/feed")},
+	})
+
+	podcast, loc, err := parser.ParseFeedFromURLWithLocation(context.Background(), "http://a.example.com/feed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "B", podcast.Title)
+	assertStr(t, "http://a.example.com/feed", loc.URL)
+	assertInt(t, 0, len(loc.Moves))
+}
+
+func TestParseFeedFromURL_NewFeedURLAuthCredentials(t *testing.T) {
+	transport := &routeTransport{
+		routes: map[string]testRoute{
+			"http://a.example.com/feed":   {status: 200, body: testMovedFeed("A", "http://a.example.com/feed2")},
+			"http://a.example.com/feed2":  {status: 200, body: testMovedFeed("A2", "https://a.example.com/feed3")},
+			"https://a.example.com/feed3": {status: 200, body: testMovedFeed("A3", "http://b.example.com/feed")},
+			"http://b.example.com/feed":   {status: 200, body: testMovedFeed("B", "")},
+		},
+		authHeaders: make(map[string]string),
+	}
+
+	parser := gopodcast.NewParser()
+	parser.FollowNewFeedURL = true
+	parser.HTTPClient = &http.Client{Transport: transport}
+	parser.AuthCredentials = &gopodcast.AuthCredentials{Username: "user1", Password: "password1"}
+
+	podcast, err := parser.ParseFeedFromURL(context.Background(), "http://a.example.com/feed")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "B", podcast.Title)
+	// credentials are only sent to the scheme and host given by the caller
+	assertStr(t, "Basic dXNlcjE6cGFzc3dvcmQx", transport.authHeaders["http://a.example.com/feed"])
+	assertStr(t, "Basic dXNlcjE6cGFzc3dvcmQx", transport.authHeaders["http://a.example.com/feed2"])
+	assertStr(t, "", transport.authHeaders["https://a.example.com/feed3"])
+	assertStr(t, "", transport.authHeaders["http://b.example.com/feed"])
+}
+
+func TestParseFeedFromURL_NewFeedURLMaxHops(t *testing.T) {
+	routes := make(map[string]testRoute)
+	for i := 0; i < 10; i++ {
+		routes[fmt.Sprintf("http://example.com/feed-%d", i)] = testRoute{
+			status: 200,
+			body:   testMovedFeed("P", fmt.Sprintf("http://example.com/feed-%d", i+1)),
+		}
+	}
+
+	parser := gopodcast.NewParser()
+	parser.FollowNewFeedURL = true
+	parser.MaxNewFeedURLHops = 3
+	parser.HTTPClient = newRouteClient(routes)
+
+	podcast, err := parser.ParseFeedFromURL(context.Background(), "http://example.com/feed-0")
+
+	assertNil(t, podcast)
+	assertStr(t, "too many itunes:new-feed-url hops, stopped at 'http://example.com/feed-3'", err.Error())
+}
+
+func testMovedFeed(title, newFeedURL string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<rss xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" version="2.0">
+  <channel>
+    <title>%s</title>
+    <itunes:new-feed-url>%s</itunes:new-feed-url>
+  </channel>
+</rss>`, title, newFeedURL)
+}
+
+type testRoute struct {
+	status   int
+	location string
+	body     string
+}
+
+func newRouteClient(routes map[string]testRoute) *http.Client {
+	return &http.Client{
+		Transport: &routeTransport{routes: routes},
+	}
+}
+
+// routeTransport returns a response for each URL from routes, and a 404 for
+// any other URL. The Authorization header of each request is recorded in
+// authHeaders, if set.
+type routeTransport struct {
+	routes      map[string]testRoute
+	authHeaders map[string]string
+}
+
+func (t *routeTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if t.authHeaders != nil {
+		t.authHeaders[r.URL.String()] = r.Header.Get("Authorization")
+	}
+	route, ok := t.routes[r.URL.String()]
+	if !ok {
+		route = testRoute{status: 404}
+	}
+	header := make(http.Header)
+	if route.location != "" {
+		header.Set("Location", route.location)
+	}
+	return &http.Response{
+		StatusCode: route.status,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewBufferString(route.body)),
+		Request:    r,
+	}, nil
+}
@@ -0,0 +1,133 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+const mediaFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss xmlns:media="http://search.yahoo.com/mrss/" version="2.0">
+  <channel>
+    <title>Test podcast 1</title>
+    <description>Test description</description>
+    <media:thumbnail url="https://www.example.com/channel.jpg"/>
+    <media:rating scheme="urn:simple">nonadult</media:rating>
+    <item>
+      <title>Test episode 1</title>
+      <description>Episode description</description>
+      <media:title type="plain">Media title</media:title>
+      <media:description type="html">&lt;p&gt;Media description&lt;/p&gt;</media:description>
+      <media:credit role="producer" scheme="urn:ebu">Test producer</media:credit>
+      <media:group>
+        <media:content url="https://www.example.com/1080.mp4" fileSize="1000" type="video/mp4" medium="video" isDefault="true" expression="full" bitrate="4000" framerate="29.97" duration="600" height="1080" width="1920"/>
+        <media:content url="https://www.example.com/720.mp4" type="video/mp4" bitrate="1500.5" duration="600.25" height="720" width="1280"/>
+        <media:thumbnail url="https://www.example.com/group.jpg" height="720" width="1280" time="00:00:10"/>
+      </media:group>
+      <media:content url="https://www.example.com/audio.mp3" type="audio/mpeg" samplingrate="44.1" channels="2">
+        <media:player url="https://www.example.com/embed" height="200" width="400"/>
+        <media:rating>adult</media:rating>
+      </media:content>
+    </item>
+  </channel>
+</rss>`
+
+func TestParseFeed_Media(t *testing.T) {
+	podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(mediaFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertInt(t, 1, len(podcast.MediaThumbnails))
+	assertStr(t, "https://www.example.com/channel.jpg", podcast.MediaThumbnails[0].URL)
+	assertInt(t, 1, len(podcast.MediaRatings))
+	assertStr(t, "urn:simple", podcast.MediaRatings[0].Scheme)
+	assertStr(t, "nonadult", podcast.MediaRatings[0].Text)
+	assertStr(t, "Test description", podcast.Description.Text)
+
+	item := podcast.Items[0]
+	assertStr(t, "Test episode 1", item.Title)
+	assertStr(t, "Episode description", item.Description.Text)
+	assertStr(t, "plain", item.MediaTitle.Type)
+	assertStr(t, "Media title", item.MediaTitle.Text)
+	assertStr(t, "html", item.MediaDescription.Type)
+	assertStr(t, "<p>Media description</p>", item.MediaDescription.Text)
+	assertInt(t, 1, len(item.MediaCredits))
+	assertStr(t, "producer", item.MediaCredits[0].Role)
+	assertStr(t, "urn:ebu", item.MediaCredits[0].Scheme)
+	assertStr(t, "Test producer", item.MediaCredits[0].Text)
+
+	assertInt(t, 1, len(item.MediaGroups))
+	group := item.MediaGroups[0]
+	assertInt(t, 2, len(group.Contents))
+	content := group.Contents[0]
+	assertStr(t, "https://www.example.com/1080.mp4", content.URL)
+	assertInt(t, 1000, int(content.FileSize))
+	assertStr(t, "video/mp4", content.Type)
+	assertStr(t, "video", content.Medium)
+	assertBool(t, true, bool(*content.IsDefault))
+	assertStr(t, "full", content.Expression)
+	assertTrue(t, content.Bitrate == 4000)
+	assertTrue(t, content.Framerate == 29.97)
+	assertTrue(t, content.Duration == 600)
+	assertInt(t, 1080, content.Height)
+	assertInt(t, 1920, content.Width)
+	assertNil(t, group.Contents[1].IsDefault)
+	assertTrue(t, group.Contents[1].Bitrate == 1500.5)
+	assertTrue(t, group.Contents[1].Duration == 600.25)
+	assertInt(t, 1, len(group.Thumbnails))
+	assertInt(t, 720, group.Thumbnails[0].Height)
+	assertStr(t, "00:00:10", group.Thumbnails[0].Time)
+
+	assertInt(t, 1, len(item.MediaContents))
+	content = item.MediaContents[0]
+	assertTrue(t, content.SamplingRate == 44.1)
+	assertInt(t, 2, content.Channels)
+	assertStr(t, "https://www.example.com/embed", content.Player.URL)
+	assertInt(t, 400, content.Player.Width)
+	assertInt(t, 1, len(content.Ratings))
+	assertStr(t, "adult", content.Ratings[0].Text)
+
+	assertInt(t, 0, len(podcast.UnknownElements))
+	assertInt(t, 0, len(item.UnknownElements))
+}
+
+func TestWriteFeed_Media(t *testing.T) {
+	podcast := &gopodcast.Podcast{
+		Title: "Test title",
+		Items: []*gopodcast.Item{
+			{
+				Title: "A podcast 1",
+				MediaContents: []gopodcast.MediaContent{
+					{
+						URL:    "https://www.example.com/video.mp4",
+						Type:   "video/mp4",
+						Height: 720,
+						Thumbnails: []gopodcast.MediaThumbnail{
+							{URL: "https://www.example.com/video.jpg"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	err := podcast.WriteFeedXML(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	assertTrue(t, strings.Contains(out, `xmlns:media="http://search.yahoo.com/mrss/"`))
+	assertTrue(t, strings.Contains(out, `<media:content url="https://www.example.com/video.mp4" type="video/mp4" height="720"><media:thumbnail url="https://www.example.com/video.jpg"></media:thumbnail></media:content>`))
+
+	// and can be parsed again
+	written, err := gopodcast.NewParser().ParseFeed(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, "https://www.example.com/video.jpg", written.Items[0].MediaContents[0].Thumbnails[0].URL)
+}
@@ -0,0 +1,179 @@
+package gopodcast
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+)
+
+// Namespace is an XML namespace declaration.
+type Namespace struct {
+	Prefix string `json:"prefix,omitempty"`
+	URI    string `json:"uri,omitempty"`
+}
+
+// builtinNamespaces are the namespaces used in struct tags. The first
+// numFixedNamespaces are always declared when writing a feed, see emptyFeed,
+// and the others are declared only when used. Keep in sync with nsToURL in
+// generate/main.go.
+var builtinNamespaces = []Namespace{
+	{Prefix: "content", URI: "http://purl.org/rss/1.0/modules/content/"},
+	{Prefix: "podcast", URI: "https://podcastindex.org/namespace/1.0"},
+	{Prefix: "atom", URI: "http://www.w3.org/2005/Atom"},
+	{Prefix: "itunes", URI: "http://www.itunes.com/dtds/podcast-1.0.dtd"},
+	{Prefix: "googleplay", URI: "http://www.google.com/schemas/play-podcasts/1.0"},
+	{Prefix: "media", URI: "http://search.yahoo.com/mrss/"},
+	{Prefix: "dc", URI: "http://purl.org/dc/elements/1.1/"},
+	{Prefix: "sy", URI: "http://purl.org/rss/1.0/modules/syndication/"},
+	{Prefix: "spotify", URI: "http://www.spotify.com/ns/rss"},
+	{Prefix: "psc", URI: "http://podlove.org/simple-chapters"},
+}
+
+const numFixedNamespaces = 4
+
+const xmlNamespaceURL = "http://www.w3.org/XML/1998/namespace"
+
+func builtinNamespaceURL(prefix string) (string, bool) {
+	for _, ns := range builtinNamespaces {
+		if ns.Prefix == prefix {
+			return ns.URI, true
+		}
+	}
+	return "", false
+}
+
+// canonicalNamespace returns the URL of the builtin namespace which url is a
+// variation of, differing only by case or a trailing slash, which are both
+// common in real feeds. Otherwise url is returned unchanged.
+func canonicalNamespace(url string) string {
+	trimmed := strings.TrimSuffix(url, "/")
+	for _, ns := range builtinNamespaces {
+		if strings.EqualFold(trimmed, strings.TrimSuffix(ns.URI, "/")) {
+			return ns.URI
+		}
+	}
+	return url
+}
+
+// writeNamespaces returns the namespaces to declare when writing the feed,
+// starting with those always declared. Other builtin namespaces are included
+// if used, followed by the namespaces of unknown elements and extensions
+// with the prefix they were declared with when parsed or registered. Every
+// namespace in p.Namespaces is declared, used or not, so that a parsed feed
+// keeps its declarations and NewFeedWriter, which can't know which
+// namespaces later items use, declares the same namespaces.
+func (p *Podcast) writeNamespaces() []Namespace {
+	used := make(map[string]bool)
+	collectNamespaces(reflect.ValueOf(p).Elem(), used)
+	for _, ns := range p.Namespaces {
+		used[canonicalNamespace(ns.URI)] = true
+	}
+	return p.declareNamespaces(used)
+}
+
+// declareNamespaces returns the namespaces to declare when writing the feed,
+// given the namespaces it uses, as described for writeNamespaces.
+func (p *Podcast) declareNamespaces(used map[string]bool) []Namespace {
+	namespaces := builtinNamespaces[:numFixedNamespaces:numFixedNamespaces]
+	for _, ns := range builtinNamespaces[numFixedNamespaces:] {
+		namespaces = addUsedNamespace(namespaces, used, ns)
+	}
+	for _, ns := range p.Namespaces {
+		if _, ok := builtinNamespaceURL(ns.Prefix); !ok {
+			namespaces = addUsedNamespace(namespaces, used, ns)
+		}
+	}
+	for _, ext := range p.Extensions {
+		namespaces = addUsedNamespace(namespaces, used, Namespace{Prefix: ext.Prefix, URI: ext.Namespace})
+	}
+	for _, item := range p.Items {
+		for _, ext := range item.Extensions {
+			namespaces = addUsedNamespace(namespaces, used, Namespace{Prefix: ext.Prefix, URI: ext.Namespace})
+		}
+	}
+	return namespaces
+}
+
+// addUsedNamespace adds ns to namespaces if it is used, and neither its
+// prefix nor URI has already been added.
+func addUsedNamespace(namespaces []Namespace, used map[string]bool, ns Namespace) []Namespace {
+	if !used[ns.URI] || hasNamespacePrefix(namespaces, ns.Prefix) || hasNamespaceURI(namespaces, ns.URI) {
+		return namespaces
+	}
+	return append(namespaces, ns)
+}
+
+// collectNamespaces records the namespaces used by the non-empty fields,
+// unknown elements and extensions of v, and of any items within it.
+func collectNamespaces(v reflect.Value, used map[string]bool) {
+	info := getEncodeInfo(v.Type())
+
+	for _, f := range info.fields {
+		fv := v.Field(f.index)
+		if isEmptyValue(fv) {
+			continue
+		}
+		if f.name.Space != "" {
+			used[f.name.Space] = true
+		}
+		switch {
+		case fv.Kind() == reflect.Slice && hasUnknownFields(fv.Type().Elem()):
+			for i := 0; i < fv.Len(); i++ {
+				el := fv.Index(i)
+				if el.Kind() == reflect.Pointer {
+					if el.IsNil() {
+						continue
+					}
+					el = el.Elem()
+				}
+				collectNamespaces(el, used)
+			}
+		case fv.Kind() == reflect.Pointer && hasUnknownFields(fv.Type()):
+			collectNamespaces(fv.Elem(), used)
+		case fv.Kind() == reflect.Struct && hasUnknownFields(fv.Type()):
+			collectNamespaces(fv, used)
+		}
+	}
+
+	if info.unknown >= 0 {
+		collectUnknownNamespaces(v.Field(info.unknown).Interface().([]UnknownElement), used)
+	}
+	if info.unknownAttrs >= 0 {
+		for _, a := range v.Field(info.unknownAttrs).Interface().([]xml.Attr) {
+			used[a.Name.Space] = true
+		}
+	}
+	if info.extensions >= 0 {
+		for _, ext := range v.Field(info.extensions).Interface().([]Extension) {
+			used[ext.Namespace] = true
+		}
+	}
+}
+
+func collectUnknownNamespaces(els []UnknownElement, used map[string]bool) {
+	for _, el := range els {
+		used[el.XMLName.Space] = true
+		for _, a := range el.Attrs {
+			used[a.Name.Space] = true
+		}
+		collectUnknownNamespaces(el.Children, used)
+	}
+}
+
+func hasNamespacePrefix(namespaces []Namespace, prefix string) bool {
+	for _, ns := range namespaces {
+		if ns.Prefix == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+func hasNamespaceURI(namespaces []Namespace, uri string) bool {
+	for _, ns := range namespaces {
+		if ns.URI == uri {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package gopodcast
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"sync"
+)
+
+const defaultOPMLConcurrency = 4
+
+// OPML is an OPML 1.0 or 2.0 document, the format used by podcast apps to
+// import and export subscriptions. Subscriptions are outlines with an xmlUrl,
+// usually with type "rss", which may be nested inside folder outlines.
+type OPML struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    OPMLHead `xml:"head"`
+	Body    OPMLBody `xml:"body"`
+}
+
+type OPMLHead struct {
+	Title        string `xml:"title,omitempty"`
+	DateCreated  *Time  `xml:"dateCreated,omitempty"`
+	DateModified *Time  `xml:"dateModified,omitempty"`
+	OwnerName    string `xml:"ownerName,omitempty"`
+	OwnerEmail   string `xml:"ownerEmail,omitempty"`
+}
+
+type OPMLBody struct {
+	Outlines []OPMLOutline `xml:"outline"`
+}
+
+type OPMLOutline struct {
+	Text        string        `xml:"text,attr"`
+	Title       string        `xml:"title,attr,omitempty"`
+	Type        string        `xml:"type,attr,omitempty"`
+	XMLURL      string        `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL     string        `xml:"htmlUrl,attr,omitempty"`
+	Description string        `xml:"description,attr,omitempty"`
+	Language    string        `xml:"language,attr,omitempty"`
+	Outlines    []OPMLOutline `xml:"outline"`
+}
+
+// ParseOPML parses an OPML document.
+func ParseOPML(r io.Reader) (*OPML, error) {
+	var o OPML
+	d := xml.NewDecoder(r)
+	d.CharsetReader = CharsetReader
+	if err := d.Decode(&o); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// WriteOPML writes the document as OPML, version 2.0 unless Version is set.
+func (o *OPML) WriteOPML(w io.Writer) error {
+	_, err := w.Write([]byte(xml.Header))
+	if err != nil {
+		return err
+	}
+
+	doc := *o
+	if doc.Version == "" {
+		doc.Version = "2.0"
+	}
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	return e.Encode(doc)
+}
+
+// Feeds returns the outlines of every feed in the document, including those
+// in folders, in document order.
+func (o *OPML) Feeds() []OPMLOutline {
+	var feeds []OPMLOutline
+	var walk func(outlines []OPMLOutline)
+	walk = func(outlines []OPMLOutline) {
+		for _, outline := range outlines {
+			if outline.XMLURL != "" {
+				feeds = append(feeds, outline)
+			}
+			walk(outline.Outlines)
+		}
+	}
+	walk(o.Body.Outlines)
+	return feeds
+}
+
+// OPMLOutline returns an outline for the podcast, subscribed to at feedURL.
+func (p *Podcast) OPMLOutline(feedURL string) OPMLOutline {
+	return OPMLOutline{
+		Text:        p.Title,
+		Title:       p.Title,
+		Type:        "rss",
+		XMLURL:      feedURL,
+		HTMLURL:     p.Link,
+		Description: p.ITunesSubtitle,
+		Language:    p.Language,
+	}
+}
+
+// OPMLFeedResult is the result of parsing one of the feeds in an OPML
+// document. Err is set if the feed couldn't be fetched or parsed.
+type OPMLFeedResult struct {
+	Outline OPMLOutline
+	Podcast *Podcast
+	Err     error
+}
+
+// ParseOPMLFeeds parses every feed in an OPML document using
+// ParseFeedFromURL, with a pool of concurrency workers (4 if not set) so
+// that at most that many feeds are fetched at once. A result is returned for
+// each of o.Feeds, in the same order, and a feed failing doesn't stop the
+// others being parsed.
+func (p *Parser) ParseOPMLFeeds(ctx context.Context, o *OPML, concurrency int) []OPMLFeedResult {
+	if concurrency <= 0 {
+		concurrency = defaultOPMLConcurrency
+	}
+
+	feeds := o.Feeds()
+	results := make([]OPMLFeedResult, len(feeds))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(concurrency, len(feeds)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+				results[i].Podcast, results[i].Err = p.ParseFeedFromURL(ctx, feeds[i].XMLURL)
+			}
+		}()
+	}
+	for i, outline := range feeds {
+		results[i].Outline = outline
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,177 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+func TestParseOPML(t *testing.T) {
+	f, err := os.Open("testdata/test-subscriptions.opml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := gopodcast.ParseOPML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "1.0", o.Version)
+	assertStr(t, "Test subscriptions", o.Head.Title)
+	assertStr(t, "2024-12-27T21:30:00Z", time.Time(*o.Head.DateCreated).Format(time.RFC3339))
+	assertInt(t, 3, len(o.Body.Outlines))
+	assertStr(t, "Comedy", o.Body.Outlines[1].Text)
+	assertInt(t, 2, len(o.Body.Outlines[1].Outlines))
+
+	feeds := o.Feeds()
+	assertInt(t, 3, len(feeds))
+	assertStr(t, "https://www.example.com/feed-1", feeds[0].XMLURL)
+	assertStr(t, "https://www.example.com/podcast-1", feeds[0].HTMLURL)
+	assertStr(t, "rss", feeds[0].Type)
+	assertStr(t, "Test podcast 2", feeds[1].Title)
+	assertStr(t, "https://www.example.com/feed-3", feeds[2].XMLURL)
+}
+
+func TestWriteOPML(t *testing.T) {
+	podcast := &gopodcast.Podcast{
+		Title:          "Test podcast 1",
+		Link:           "https://www.example.com/podcast-1",
+		Language:       "en",
+		ITunesSubtitle: "Test subtitle",
+	}
+	o := &gopodcast.OPML{
+		Head: gopodcast.OPMLHead{Title: "Test subscriptions"},
+		Body: gopodcast.OPMLBody{Outlines: []gopodcast.OPMLOutline{
+			podcast.OPMLOutline("https://www.example.com/feed-1"),
+			{Text: "Comedy", Outlines: []gopodcast.OPMLOutline{
+				{Text: "Test podcast 2", Type: "rss", XMLURL: "https://www.example.com/feed-2"},
+			}},
+		}},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := o.WriteOPML(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head>
+    <title>Test subscriptions</title>
+  </head>
+  <body>
+    <outline text="Test podcast 1" title="Test podcast 1" type="rss" xmlUrl="https://www.example.com/feed-1" htmlUrl="https://www.example.com/podcast-1" description="Test subtitle" language="en"></outline>
+    <outline text="Comedy">
+      <outline text="Test podcast 2" type="rss" xmlUrl="https://www.example.com/feed-2"></outline>
+    </outline>
+  </body>
+</opml>`
+	assertStr(t, exp, buf.String())
+
+	// and can be parsed again
+	written, err := gopodcast.ParseOPML(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertInt(t, 2, len(written.Feeds()))
+}
+
+func TestParseOPMLFeeds(t *testing.T) {
+	feed, err := os.ReadFile("testdata/test-feed-all.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var inFlight, maxInFlight atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(feed)
+	}))
+	defer srv.Close()
+
+	var outlines []gopodcast.OPMLOutline
+	for _, path := range []string{"/1", "/2", "/missing", "/4", "/5"} {
+		outlines = append(outlines, gopodcast.OPMLOutline{Text: path, Type: "rss", XMLURL: srv.URL + path})
+	}
+	o := &gopodcast.OPML{Body: gopodcast.OPMLBody{Outlines: outlines}}
+
+	results := gopodcast.NewParser().ParseOPMLFeeds(context.Background(), o, 2)
+
+	assertInt(t, 5, len(results))
+	for i, res := range results {
+		assertStr(t, outlines[i].XMLURL, res.Outline.XMLURL)
+		if i == 2 {
+			assertNil(t, res.Podcast)
+			assertStr(t, "non-200 http response '404'", res.Err.Error())
+			continue
+		}
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		assertStr(t, "Test podcast 1", res.Podcast.Title)
+	}
+	assertTrue(t, maxInFlight.Load() <= 2)
+}
+
+func TestParseOPMLFeeds_BoundedWorkers(t *testing.T) {
+	feed, err := os.ReadFile("testdata/test-feed-all.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var maxGoroutines atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int32(runtime.NumGoroutine())
+		for {
+			m := maxGoroutines.Load()
+			if n <= m || maxGoroutines.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		_, _ = w.Write(feed)
+	}))
+	defer srv.Close()
+
+	var outlines []gopodcast.OPMLOutline
+	for i := range 200 {
+		outlines = append(outlines, gopodcast.OPMLOutline{Type: "rss", XMLURL: fmt.Sprintf("%s/%d", srv.URL, i)})
+	}
+	o := &gopodcast.OPML{Body: gopodcast.OPMLBody{Outlines: outlines}}
+
+	results := gopodcast.NewParser().ParseOPMLFeeds(context.Background(), o, 2)
+	assertInt(t, 200, len(results))
+	// goroutines aren't started for every outline
+	assertTrue(t, maxGoroutines.Load() < 50)
+
+	// and outlines left when the context is done aren't fetched
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, res := range gopodcast.NewParser().ParseOPMLFeeds(ctx, o, 2) {
+		assertTrue(t, errors.Is(res.Err, context.Canceled))
+	}
+}
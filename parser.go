@@ -6,12 +6,33 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
 )
 
 type Parser struct {
-	HTTPClient      *http.Client
-	UserAgent       string
+	HTTPClient *http.Client
+	UserAgent  string
+
+	// AuthCredentials are sent as basic auth when fetching a feed, but only
+	// to the scheme and host of the URL given, e.g. not when following
+	// itunes:new-feed-url to another host. They are never sent when fetching
+	// transcripts or media linked from a feed.
 	AuthCredentials *AuthCredentials
+
+	// FollowNewFeedURL makes ParseFeedFromURL follow itunes:new-feed-url
+	// links until it reaches a feed which has not moved, following at most
+	// MaxNewFeedURLHops links (5 if not set).
+	FollowNewFeedURL  bool
+	MaxNewFeedURLHops int
+
+	// GooglePlayFallback makes ParseFeed fill in iTunes values missing from
+	// a feed, such as itunes:author and itunes:image, with their googleplay
+	// equivalents.
+	GooglePlayFallback bool
+
+	extensions []extensionType
 }
 
 type AuthCredentials struct {
@@ -28,40 +49,169 @@ func NewParser() *Parser {
 	}
 }
 
-func (p *Parser) ParseFeedFromURL(ctx context.Context, url string) (pc *Podcast, err error) {
+func (p *Parser) ParseFeedFromURL(ctx context.Context, url string) (*Podcast, error) {
+	pc, _, err := p.ParseFeedFromURLWithLocation(ctx, url)
+	return pc, err
+}
+
+func (p *Parser) parseFeedFromURL(ctx context.Context, client *http.Client, url, authURL string) (pc *Podcast, err error) {
+	res, err := p.getWithClient(ctx, client, url, authURL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		errVal := res.Body.Close()
+		if errVal != nil {
+			err = errVal
+		}
+	}()
+
+	return p.parseFeed(res.Body, contentTypeCharset(res.Header.Get("Content-Type")))
+}
+
+// get makes a GET request to url using the parser's http client and user
+// agent. It is used for URLs taken from a feed, such as transcripts and
+// media, so auth credentials are never sent. An error is returned for
+// non-2xx responses.
+func (p *Parser) get(ctx context.Context, url string) (*http.Response, error) {
+	return p.getWithClient(ctx, p.HTTPClient, url, "")
+}
+
+// getWithClient makes a GET request as described by get, using client. Auth
+// credentials are only sent if url has the same scheme and host as authURL,
+// the URL given by the caller, so that they aren't sent to other hosts when
+// following links in a feed. No credentials are sent if authURL is empty.
+func (p *Parser) getWithClient(ctx context.Context, client *http.Client, url, authURL string) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", p.UserAgent)
 
-	if p.AuthCredentials != nil && p.AuthCredentials.Username != "" && p.AuthCredentials.Password != "" {
+	if p.AuthCredentials != nil && p.AuthCredentials.Username != "" && p.AuthCredentials.Password != "" && sameOrigin(req.URL, authURL) {
 		req.SetBasicAuth(p.AuthCredentials.Username, p.AuthCredentials.Password)
 	}
 
-	res, err := p.HTTPClient.Do(req)
+	res, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		errVal := res.Body.Close()
-		if errVal != nil {
-			err = errVal
-		}
-	}()
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		_ = res.Body.Close()
 		return nil, fmt.Errorf("non-200 http response '%d'", res.StatusCode)
 	}
 
-	return p.ParseFeed(res.Body)
+	return res, nil
 }
 
+// sameOrigin returns whether u has the same scheme and host as rawURL.
+func sameOrigin(u *url.URL, rawURL string) bool {
+	if rawURL == "" {
+		return false
+	}
+	o, err := url.Parse(rawURL)
+	return err == nil && strings.EqualFold(u.Scheme, o.Scheme) && strings.EqualFold(u.Host, o.Host)
+}
+
+// ParseFeed parses an RSS 2.0, RSS 1.0 (RDF) or Atom 1.0 feed, detected from
+// its root element. Feeds in charsets other than UTF-8 are converted using
+// CharsetReader.
 func (p *Parser) ParseFeed(r io.Reader) (*Podcast, error) {
+	return p.parseFeed(r, "")
+}
+
+// parseFeed parses a feed, in the given charset if set, see newFeedDecoder.
+func (p *Parser) parseFeed(r io.Reader, charset string) (*Podcast, error) {
+	d := newFeedDecoder(r, charset)
+	root, err := rootElement(d)
+	if err != nil {
+		return nil, err
+	}
+	return p.decodeFeed(root, &replayTokenReader{toks: []xml.Token{root}, r: d})
+}
+
+// decodeFeed decodes the feed read from toks, which starts with the given
+// root element.
+func (p *Parser) decodeFeed(root xml.StartElement, toks xml.TokenReader) (*Podcast, error) {
+	rec := newElementRecorder(toks)
+
+	var podcast *Podcast
+	var err error
+	switch root.Name {
+	case xml.Name{Space: atomNamespaceURL, Local: "feed"}:
+		podcast, err = decodeAtomFeed(rec)
+	case xml.Name{Space: rdfNamespaceURL, Local: "RDF"}:
+		podcast, err = decodeRDFFeed(rec)
+	default:
+		podcast, err = decodeRSSFeed(rec)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if podcast != nil {
+		rec.apply(podcast)
+		normalizeParsedPodcast(podcast)
+		if p.GooglePlayFallback {
+			applyGooglePlayFallback(podcast)
+		}
+		if err := p.decodeExtensions(podcast); err != nil {
+			return nil, err
+		}
+	}
+	return podcast, nil
+}
+
+var rssKnownElements = newKnownElements(reflect.TypeFor[xmlFixfeed](), reflect.TypeFor[xmlFixPodcast](), reflect.TypeFor[xmlFixItem]())
+
+func decodeRSSFeed(rec *elementRecorder) (*Podcast, error) {
+	rec.known = rssKnownElements
+
 	var feed xmlFixfeed
-	err := xml.NewDecoder(r).Decode(&feed)
+	err := xml.NewTokenDecoder(rec).Decode(&feed)
 	if err != nil {
 		return nil, err
 	}
 	return feed.Translate().Channel, nil
 }
+
+// rootElement reads tokens from d up to and including the root element.
+func rootElement(d *xml.Decoder) (xml.StartElement, error) {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Copy(), nil
+		}
+	}
+}
+
+// replayTokenReader is an xml.TokenReader which returns tokens which have
+// already been read, followed by the rest of the tokens from r, if set.
+type replayTokenReader struct {
+	toks []xml.Token
+	r    xml.TokenReader
+}
+
+func (r *replayTokenReader) Token() (xml.Token, error) {
+	if len(r.toks) > 0 {
+		tok := r.toks[0]
+		r.toks = r.toks[1:]
+		return tok, nil
+	}
+	if r.r == nil {
+		return nil, io.EOF
+	}
+	return r.r.Token()
+}
+
+// normalizeParsedPodcast tidies values which can't be cleaned up during
+// unmarshalling, and populates deprecated fields.
+func normalizeParsedPodcast(pc *Podcast) {
+	if len(pc.PodcastFundings) > 0 {
+		pc.PodcastFunding = &pc.PodcastFundings[0]
+	}
+	pc.ITunesApplePodcastsVerify = strings.TrimSpace(pc.ITunesApplePodcastsVerify)
+}
@@ -0,0 +1,102 @@
+package gopodcast
+
+import (
+	"encoding/xml"
+	"reflect"
+)
+
+const (
+	rdfNamespaceURL   = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	rss10NamespaceURL = "http://purl.org/rss/1.0/"
+)
+
+// rdfFeed is an RSS 1.0 feed, where the channel, image and items are
+// siblings within the rdf:RDF root element. As with atomFeed, the channel and
+// items embed the generated structs, which decode the RSS 1.0 elements which
+// share their names with RSS 2.0, along with extension and unknown elements.
+type rdfFeed struct {
+	XMLName xml.Name     `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# RDF"`
+	Channel rdfChannel   `xml:"http://purl.org/rss/1.0/ channel"`
+	Image   *xmlFixImage `xml:"http://purl.org/rss/1.0/ image"`
+	Items   []*rdfItem   `xml:"http://purl.org/rss/1.0/ item"`
+}
+
+type rdfChannel struct {
+	About string `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+
+	// ImageRef and ItemRefs refer to the image and items by URL, and are
+	// decoded only so they aren't kept as unknown elements
+	ImageRef struct{} `xml:"http://purl.org/rss/1.0/ image"`
+	ItemRefs struct{} `xml:"http://purl.org/rss/1.0/ items"`
+
+	xmlFixPodcast
+}
+
+type rdfItem struct {
+	About      string         `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+	Enclosures []rdfEnclosure `xml:"http://purl.oclc.org/net/rss_2.0/enc# enclosure"`
+
+	xmlFixItem
+}
+
+// rdfEnclosure is an enclosure from the mod_enclosure module
+type rdfEnclosure struct {
+	Resource string `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# resource,attr"`
+	Length   int64  `xml:"http://purl.oclc.org/net/rss_2.0/enc# length,attr"`
+	Type     string `xml:"http://purl.oclc.org/net/rss_2.0/enc# type,attr"`
+}
+
+var rdfKnownElements = newKnownElements(reflect.TypeFor[rdfFeed](), reflect.TypeFor[rdfChannel](), reflect.TypeFor[rdfItem]())
+
+func decodeRDFFeed(rec *elementRecorder) (*Podcast, error) {
+	// items are siblings of the channel, so the channel's own child elements
+	// can't be told apart from those of the image and items by depth
+	rec.channelDepth = 2
+	rec.known = rdfKnownElements
+	rec.feedSpaces = append(rec.feedSpaces, rss10NamespaceURL)
+
+	var feed rdfFeed
+	err := xml.NewTokenDecoder(rec).Decode(&feed)
+	if err != nil {
+		return nil, err
+	}
+	return feed.translate(), nil
+}
+
+func (f *rdfFeed) translate() *Podcast {
+	pc := f.Channel.xmlFixPodcast.Translate()
+
+	pc.Link = firstNonEmpty(pc.Link, f.Channel.About)
+	if f.Image != nil {
+		pc.Image = f.Image.Translate()
+	}
+	if t, ok := pc.DCDate.Value(); ok && pc.PubDate == "" {
+		pc.PubDate = NewDate(t)
+	}
+	pc.Language = firstNonEmpty(pc.Language, pc.DCLanguage)
+	pc.ITunesAuthor = firstNonEmpty(pc.ITunesAuthor, pc.DCCreator)
+
+	pc.Items = nil
+	for _, item := range f.Items {
+		pc.Items = append(pc.Items, item.translate())
+	}
+	return pc
+}
+
+func (i *rdfItem) translate() *Item {
+	item := i.xmlFixItem.Translate()
+
+	if item.GUID.Text == "" {
+		item.GUID = ItemGUID{Text: i.About}
+	}
+	item.Link = firstNonEmpty(item.Link, i.About)
+	if len(i.Enclosures) > 0 {
+		enc := i.Enclosures[0]
+		item.Enclosure = Enclosure{URL: enc.Resource, Type: enc.Type, Length: enc.Length}
+	}
+	if item.PubDate == nil {
+		item.PubDate = item.DCDate
+	}
+	item.ITunesAuthor = firstNonEmpty(item.ITunesAuthor, item.DCCreator)
+	return item
+}
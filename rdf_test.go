@@ -0,0 +1,82 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+func TestParseFeed_RDF(t *testing.T) {
+	f, err := os.Open("testdata/test-feed-rdf.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	podcast, err := gopodcast.NewParser().ParseFeed(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "Test podcast 1", podcast.Title)
+	assertStr(t, "Test podcast description goes here", podcast.Description.Text)
+	assertStr(t, "https://www.example.com", podcast.Link)
+	assertStr(t, "en-gb", podcast.Language)
+	assertStr(t, "Test author", podcast.ITunesAuthor)
+	assertDate(t, "2024-12-27T21:30:00Z", podcast.PubDate)
+	assertStr(t, "https://www.example.com/logo.jpg", podcast.Image.URL)
+	assertStr(t, "Test podcast 1", podcast.Image.Title)
+	assertBool(t, true, bool(podcast.ITunesExplicit))
+	assertInt(t, 1, len(podcast.UnknownElements))
+	assertStr(t, "rating", podcast.UnknownElements[0].XMLName.Local)
+
+	assertInt(t, 2, len(podcast.Items))
+	item := podcast.Items[0]
+	assertStr(t, "Test episode 1", item.Title)
+	assertStr(t, "https://www.example.com/episode-1", item.GUID.Text)
+	assertStr(t, "https://www.example.com/episode-1", item.Link)
+	assertStr(t, "Test episode summary", item.Description.Text)
+	assertStr(t, "2024-12-20T09:00:00Z", time.Time(*item.PubDate).UTC().Format(time.RFC3339))
+	assertStr(t, "Episode author", item.ITunesAuthor)
+	assertStr(t, "https://www.example.com/episode-1.mp3", item.Enclosure.URL)
+	assertStr(t, "audio/mpeg", item.Enclosure.Type)
+	assertInt(t, 1001, int(item.Enclosure.Length))
+	assertStr(t, "600", item.ITunesDuration)
+	assertInt(t, 0, len(item.UnknownElements))
+
+	item = podcast.Items[1]
+	assertStr(t, "https://www.example.com/episode-2", item.GUID.Text)
+	assertStr(t, "https://www.example.com/episode-2.mp3", item.Enclosure.URL)
+	assertInt(t, 1, len(item.UnknownElements))
+	assertStr(t, "rating", item.UnknownElements[0].XMLName.Local)
+}
+
+func TestParseFeed_RDFRequiredValues(t *testing.T) {
+	f, err := os.Open("testdata/test-feed-rdf.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	podcast, err := gopodcast.NewParser().ParseFeed(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkRequiredFeedValuesPresent(t, podcast)
+
+	// and can be written as RSS 2.0
+	buf := &bytes.Buffer{}
+	if err := podcast.WriteFeedXML(buf); err != nil {
+		t.Fatal(err)
+	}
+	written, err := gopodcast.NewParser().ParseFeed(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, "Test podcast 1", written.Title)
+	assertInt(t, 2, len(written.Items))
+	assertStr(t, "https://www.example.com/episode-1.mp3", written.Items[0].Enclosure.URL)
+	assertInt(t, 1, len(written.Items[1].UnknownElements))
+}
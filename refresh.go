@@ -0,0 +1,54 @@
+package gopodcast
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// NextRefresh returns the earliest time after lastFetched at which a polite
+// client should fetch the feed again. This is lastFetched plus the feed's ttl,
+// or defaultInterval if it has no ttl, moved forward out of any hours or days
+// listed in skipHours and skipDays.
+func (p *Podcast) NextRefresh(lastFetched time.Time, defaultInterval time.Duration) time.Time {
+	interval := defaultInterval
+	if ttl, ok := p.TTL.Value(); ok && ttl > 0 {
+		interval = time.Duration(ttl) * time.Minute
+	}
+	next := lastFetched.Add(interval)
+
+	// a week of hours covers every combination of skipped hours and days,
+	// so give up if the feed asks to never be refreshed
+	t := next.UTC()
+	for range 7 * 24 {
+		switch {
+		case p.skipsDay(t.Weekday()):
+			t = t.Truncate(time.Hour).Add(time.Duration(24-t.Hour()) * time.Hour)
+		case p.skipsHour(t.Hour()):
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		default:
+			return t.In(lastFetched.Location())
+		}
+	}
+	return next
+}
+
+func (p *Podcast) skipsHour(hour int) bool {
+	if p.SkipHours == nil {
+		return false
+	}
+	return slices.ContainsFunc(p.SkipHours.Hours, func(h Int) bool {
+		// some feeds use 24 for midnight
+		n, ok := h.Value()
+		return ok && n%24 == hour
+	})
+}
+
+func (p *Podcast) skipsDay(day time.Weekday) bool {
+	if p.SkipDays == nil {
+		return false
+	}
+	return slices.ContainsFunc(p.SkipDays.Days, func(d string) bool {
+		return strings.EqualFold(strings.TrimSpace(d), day.String())
+	})
+}
@@ -0,0 +1,62 @@
+package gopodcast_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+func TestNextRefresh(t *testing.T) {
+	// a Friday
+	lastFetched := time.Date(2024, 12, 27, 21, 30, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		podcast *gopodcast.Podcast
+		exp     string
+	}{
+		"default interval": {
+			podcast: &gopodcast.Podcast{},
+			exp:     "2024-12-27T22:30:00Z",
+		},
+		"ttl": {
+			podcast: &gopodcast.Podcast{TTL: gopodcast.NewInt(15)},
+			exp:     "2024-12-27T21:45:00Z",
+		},
+		"invalid ttl and hours": {
+			podcast: &gopodcast.Podcast{
+				TTL:       "1 hour",
+				SkipHours: &gopodcast.SkipHours{Hours: []gopodcast.Int{"22pm", "22"}},
+			},
+			exp: "2024-12-27T23:00:00Z",
+		},
+		"skip hours": {
+			podcast: &gopodcast.Podcast{
+				SkipHours: &gopodcast.SkipHours{Hours: []gopodcast.Int{"22", "23"}},
+			},
+			exp: "2024-12-28T00:00:00Z",
+		},
+		"skip days": {
+			podcast: &gopodcast.Podcast{
+				TTL:      gopodcast.NewInt(180),
+				SkipDays: &gopodcast.SkipDays{Days: []string{"saturday", " Sunday "}},
+			},
+			exp: "2024-12-30T00:00:00Z",
+		},
+		"skip hours and days": {
+			podcast: &gopodcast.Podcast{
+				TTL:       gopodcast.NewInt(180),
+				SkipHours: &gopodcast.SkipHours{Hours: []gopodcast.Int{"24", "1"}},
+				SkipDays:  &gopodcast.SkipDays{Days: []string{"Saturday"}},
+			},
+			exp: "2024-12-29T02:00:00Z",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			next := tc.podcast.NextRefresh(lastFetched, time.Hour)
+			assertStr(t, tc.exp, next.Format(time.RFC3339))
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+const spotifyFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss xmlns:spotify="http://www.spotify.com/ns/rss" version="2.0">
+  <channel>
+    <title>Test podcast 1</title>
+    <spotify:limit recentCount="5"/>
+    <spotify:countryOfOrigin>us GB  fr</spotify:countryOfOrigin>
+  </channel>
+</rss>`
+
+func TestParseFeed_Spotify(t *testing.T) {
+	podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(spotifyFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertInt(t, 5, podcast.SpotifyLimit.RecentCount)
+	assertStr(t, "us,gb,fr", strings.Join(podcast.SpotifyCountryOfOrigin, ","))
+	assertInt(t, 0, len(podcast.UnknownElements))
+}
+
+func TestParseFeed_SpotifyInvalidCountry(t *testing.T) {
+	feed := strings.Replace(spotifyFeed, "GB", "UK", 1)
+
+	podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// invalid codes are kept, and reported by Validate
+	assertStr(t, "us,uk,fr", strings.Join(podcast.SpotifyCountryOfOrigin, ","))
+	err = podcast.SpotifyCountryOfOrigin.Validate()
+	assertNotNil(t, err)
+	assertStr(t, "invalid country code 'uk'", err.Error())
+	if err := (gopodcast.CountryCodes{"us", "GB"}).Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriteFeed_Spotify(t *testing.T) {
+	podcast := &gopodcast.Podcast{
+		Title:                  "Test title",
+		SpotifyLimit:           &gopodcast.SpotifyLimit{RecentCount: 10},
+		SpotifyCountryOfOrigin: gopodcast.CountryCodes{"US", "gb"},
+	}
+
+	buf := &bytes.Buffer{}
+	err := podcast.WriteFeedXML(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	assertTrue(t, strings.Contains(out, `xmlns:spotify="http://www.spotify.com/ns/rss"`))
+	assertTrue(t, strings.Contains(out, `<spotify:limit recentCount="10"></spotify:limit>`))
+	assertTrue(t, strings.Contains(out, `<spotify:countryOfOrigin>us gb</spotify:countryOfOrigin>`))
+}
+
+func TestWriteFeed_SpotifyInvalidCountry(t *testing.T) {
+	feed := strings.Replace(spotifyFeed, "GB", "UK", 1)
+	podcast, err := gopodcast.NewParser().ParseFeed(strings.NewReader(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// invalid codes are written as they were parsed
+	buf := &bytes.Buffer{}
+	if err := podcast.WriteFeedXML(buf); err != nil {
+		t.Fatal(err)
+	}
+	assertTrue(t, strings.Contains(buf.String(), `<spotify:countryOfOrigin>us uk fr</spotify:countryOfOrigin>`))
+}
+
+func TestIsCountryCode(t *testing.T) {
+	assertBool(t, true, gopodcast.IsCountryCode("GB"))
+	assertBool(t, true, gopodcast.IsCountryCode("us"))
+	assertBool(t, false, gopodcast.IsCountryCode("UK"))
+	assertBool(t, false, gopodcast.IsCountryCode("USA"))
+	assertBool(t, false, gopodcast.IsCountryCode(""))
+}
@@ -0,0 +1,340 @@
+package gopodcast
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// ParseFeedStream parses a feed in the same way as ParseFeed, but without
+// reading the whole feed into memory. The channel is parsed up to its first
+// item and returned, without items, along with an iterator which parses the
+// items one at a time as it is ranged over, e.g.
+//
+//	podcast, items, err := parser.ParseFeedStream(r)
+//	...
+//	for item, err := range items {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Stopping early, such as after the newest items, stops reading the feed.
+// The iterator can only be used once, and stops after the first error.
+// Channel elements which come after the first item are skipped, and
+// namespaces declared within items are added to the podcast's Namespaces as
+// they are found.
+func (p *Parser) ParseFeedStream(r io.Reader) (*Podcast, iter.Seq2[*Item, error], error) {
+	d := newFeedDecoder(r, "")
+	root, err := rootElement(d)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s := &feedStream{p: p, d: d, root: root}
+	switch root.Name {
+	case xml.Name{Space: atomNamespaceURL, Local: "feed"}:
+		// feed > entry
+		s.itemDepth, s.itemName = 2, "entry"
+	case xml.Name{Space: rdfNamespaceURL, Local: "RDF"}:
+		// rdf:RDF > item
+		s.itemDepth, s.itemName = 2, "item"
+	default:
+		// rss > channel > item
+		s.itemDepth, s.itemName = 3, "item"
+	}
+
+	s.podcast, err = s.readChannel()
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.podcast, s.items, nil
+}
+
+// feedStream reads a feed one item at a time. Each item is decoded by
+// decodeFeed as a feed of its own, with only the elements which enclose the
+// item, so that it is parsed in exactly the same way as by ParseFeed.
+type feedStream struct {
+	p       *Parser
+	d       *xml.Decoder
+	root    xml.StartElement
+	podcast *Podcast
+
+	itemDepth int
+	itemName  string
+
+	// ancestors are the elements enclosing the items, and next is the start
+	// of the next item, or nil if there are no more
+	ancestors []xml.StartElement
+	next      *xml.StartElement
+}
+
+// readChannel decodes the elements of the feed up to its first item.
+func (s *feedStream) readChannel() (*Podcast, error) {
+	open := []xml.StartElement{s.root}
+	toks := []xml.Token{s.root}
+	for len(open) > 0 {
+		tok, err := s.d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		tok = xml.CopyToken(tok)
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if s.isItem(t, len(open)+1) {
+				s.next = &t
+				s.ancestors = open
+			} else {
+				open = append(open, t)
+			}
+		case xml.EndElement:
+			open = open[:len(open)-1]
+		}
+		if s.next != nil {
+			break
+		}
+		toks = append(toks, tok)
+	}
+
+	return s.p.decodeFeed(s.root, &replayTokenReader{toks: closeElements(toks, open)})
+}
+
+func (s *feedStream) items(yield func(*Item, error) bool) {
+	for s.next != nil {
+		item, err := s.readItem()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		if item != nil && !yield(item, nil) {
+			return
+		}
+	}
+}
+
+// readItem decodes the next item, and finds the start of the one after it.
+func (s *feedStream) readItem() (*Item, error) {
+	var toks []xml.Token
+	for _, el := range s.ancestors {
+		toks = append(toks, el)
+	}
+	toks = append(toks, *s.next)
+	s.next = nil
+
+	for depth := 1; depth > 0; {
+		tok, err := s.d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch tok.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+		toks = append(toks, xml.CopyToken(tok))
+	}
+
+	podcast, err := s.p.decodeFeed(s.root, &replayTokenReader{toks: closeElements(toks, s.ancestors)})
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range podcast.Namespaces {
+		if !hasNamespacePrefix(s.podcast.Namespaces, ns.Prefix) {
+			s.podcast.Namespaces = append(s.podcast.Namespaces, ns)
+		}
+	}
+	if err := s.findNextItem(); err != nil {
+		return nil, err
+	}
+	if len(podcast.Items) == 0 {
+		return nil, nil
+	}
+	return podcast.Items[0], nil
+}
+
+// findNextItem reads up to the start of the next item, skipping any other
+// elements, and sets next if there is one.
+func (s *feedStream) findNextItem() error {
+	depth := len(s.ancestors)
+	for {
+		tok, err := s.d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if s.isItem(t, depth+1) {
+				t = t.Copy()
+				s.next = &t
+				return nil
+			}
+			if err := s.d.Skip(); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			depth--
+			if depth < len(s.ancestors) {
+				return nil
+			}
+		}
+	}
+}
+
+func (s *feedStream) isItem(start xml.StartElement, depth int) bool {
+	return depth == s.itemDepth && start.Name.Local == s.itemName
+}
+
+// closeElements returns toks followed by the end of each of the open
+// elements.
+func closeElements(toks []xml.Token, open []xml.StartElement) []xml.Token {
+	for i := len(open) - 1; i >= 0; i-- {
+		toks = append(toks, open[i].End())
+	}
+	return toks
+}
+
+// FeedWriter writes an RSS feed one item at a time, producing the same XML as
+// WriteFeedXML without holding every item in memory. The channel is written
+// by NewFeedWriter, followed by items from WriteItem or WriteItems, and the
+// feed is finished by Close.
+type FeedWriter struct {
+	e          *xml.Encoder
+	fe         *feedEncoder
+	namespaces []Namespace
+	rss        xml.StartElement
+	channel    *structEncoder
+	closed     bool
+}
+
+var podcastItemsIndex = func() int {
+	f, _ := reflect.TypeFor[Podcast]().FieldByName("Items")
+	return f.Index[0]
+}()
+
+// NewFeedWriter writes the channel of p to w, followed by any of p.Items,
+// and returns a FeedWriter to write the rest of the items.
+//
+// The namespaces declared are the same as those declared by WriteFeedXML,
+// which always includes every namespace in p.Namespaces, such as those of a
+// feed parsed by ParseFeedStream. As later items aren't known when the
+// namespaces are declared, namespaces used only by items should be added to
+// p.Namespaces; otherwise, builtin and extension namespaces used by an item
+// but not declared are declared on the item instead.
+func NewFeedWriter(w io.Writer, p *Podcast) (*FeedWriter, error) {
+	_, err := w.Write([]byte(xml.Header))
+	if err != nil {
+		return nil, err
+	}
+
+	p = p.withLegacyFields()
+	namespaces := p.writeNamespaces()
+
+	e := xml.NewEncoder(w)
+	fw := &FeedWriter{
+		e:          e,
+		fe:         newFeedEncoder(e, namespaces),
+		namespaces: namespaces,
+		rss:        rssStart(namespaces),
+	}
+	if err := e.EncodeToken(fw.rss); err != nil {
+		return nil, err
+	}
+
+	channel := *p
+	channel.Items = nil
+	fw.channel, err = fw.fe.beginStruct(xml.StartElement{Name: xml.Name{Local: "channel"}}, reflect.ValueOf(&channel).Elem())
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.channel.encodeFieldsUntil(podcastItemsIndex); err != nil {
+		return nil, err
+	}
+	for _, item := range p.Items {
+		if err := fw.WriteItem(item); err != nil {
+			return nil, err
+		}
+	}
+	return fw, nil
+}
+
+// WriteItem writes the next item of the feed.
+func (fw *FeedWriter) WriteItem(item *Item) error {
+	if fw.closed {
+		return errors.New("feed writer is closed")
+	}
+	if item == nil {
+		return nil
+	}
+
+	fe := fw.fe
+	start := xml.StartElement{Name: xml.Name{Local: "item"}}
+	if namespaces := fw.itemNamespaces(item); len(namespaces) > 0 {
+		fe = newFeedEncoder(fw.e, append(slices.Clone(fw.namespaces), namespaces...))
+		for _, ns := range namespaces {
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:" + ns.Prefix}, Value: ns.URI})
+		}
+	}
+	return fe.encodeStruct(start, reflect.ValueOf(item).Elem())
+}
+
+// WriteItems writes each of items, such as those from ParseFeedStream,
+// stopping at the first error.
+func (fw *FeedWriter) WriteItems(items iter.Seq2[*Item, error]) error {
+	for item, err := range items {
+		if err != nil {
+			return err
+		}
+		if err := fw.WriteItem(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close writes the end of the feed. It doesn't close the underlying writer.
+func (fw *FeedWriter) Close() error {
+	if fw.closed {
+		return nil
+	}
+	fw.closed = true
+	if err := fw.channel.end(); err != nil {
+		return err
+	}
+	if err := fw.e.EncodeToken(fw.rss.End()); err != nil {
+		return err
+	}
+	return fw.e.Flush()
+}
+
+// itemNamespaces returns the builtin and extension namespaces used by item
+// which weren't declared with the channel.
+func (fw *FeedWriter) itemNamespaces(item *Item) []Namespace {
+	used := make(map[string]bool)
+	collectNamespaces(reflect.ValueOf(item).Elem(), used)
+
+	var namespaces []Namespace
+	add := func(ns Namespace) {
+		if !hasNamespacePrefix(fw.namespaces, ns.Prefix) && !hasNamespaceURI(fw.namespaces, ns.URI) {
+			namespaces = addUsedNamespace(namespaces, used, ns)
+		}
+	}
+	for _, ns := range builtinNamespaces[numFixedNamespaces:] {
+		add(ns)
+	}
+	for _, ext := range item.Extensions {
+		add(Namespace{Prefix: ext.Prefix, URI: ext.Namespace})
+	}
+	return namespaces
+}
@@ -0,0 +1,256 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+func TestParseFeedStream(t *testing.T) {
+	for _, file := range []string{"test-feed-all.xml", "test-feed-atom.xml", "test-feed-rdf.xml", "test-feed-unknown.xml"} {
+		t.Run(file, func(t *testing.T) {
+			exp := parseTestFeed(t, path.Join("testdata", file))
+
+			f, err := os.Open(path.Join("testdata", file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			podcast, items, err := gopodcast.NewParser().ParseFeedStream(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertInt(t, 0, len(podcast.Items))
+			for item, err := range items {
+				if err != nil {
+					t.Fatal(err)
+				}
+				podcast.Items = append(podcast.Items, item)
+			}
+
+			assertStr(t, jsonStr(t, exp), jsonStr(t, podcast))
+		})
+	}
+}
+
+func TestParseFeedStream_TopPodcasts(t *testing.T) {
+	for _, file := range sampleTopPodcasts {
+		t.Run(file, func(t *testing.T) {
+			exp := parseTestFeed(t, path.Join("testdata/top-podcasts", file))
+
+			f, err := os.Open(path.Join("testdata/top-podcasts", file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			podcast, items, err := gopodcast.NewParser().ParseFeedStream(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertStr(t, exp.Title, podcast.Title)
+
+			n := 0
+			for item, err := range items {
+				if err != nil {
+					t.Fatal(err)
+				}
+				assertStr(t, jsonStr(t, exp.Items[n]), jsonStr(t, item))
+				n++
+			}
+			assertInt(t, len(exp.Items), n)
+		})
+	}
+}
+
+func TestParseFeedStream_StopEarly(t *testing.T) {
+	feed := `<rss version="2.0"><channel><title>Test podcast 1</title>
+<item><title>Test episode 1</title></item>
+<item><title>Test episode 2</title></item>
+<item><title>Test episode 3</title></item>
+<item>not valid XML</iten>
+</channel></rss>`
+
+	podcast, items, err := gopodcast.NewParser().ParseFeedStream(strings.NewReader(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, "Test podcast 1", podcast.Title)
+
+	var titles []string
+	for item, err := range items {
+		if err != nil {
+			t.Fatal(err)
+		}
+		titles = append(titles, item.Title)
+		if len(titles) == 2 {
+			break
+		}
+	}
+	assertStr(t, "Test episode 1,Test episode 2", strings.Join(titles, ","))
+}
+
+func TestParseFeedStream_InvalidItem(t *testing.T) {
+	feed := `<rss version="2.0"><channel><title>Test podcast 1</title>
+<item><title>Test episode 1</title></item>
+<item><title>Test episode 2</iten></item>
+</channel></rss>`
+
+	_, items, err := gopodcast.NewParser().ParseFeedStream(strings.NewReader(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var titles []string
+	var itemErr error
+	for item, err := range items {
+		if err != nil {
+			itemErr = err
+			continue
+		}
+		titles = append(titles, item.Title)
+	}
+	assertStr(t, "Test episode 1", strings.Join(titles, ","))
+	assertNotNil(t, itemErr)
+}
+
+func parseTestFeed(t *testing.T, name string) *gopodcast.Podcast {
+	t.Helper()
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	podcast, err := gopodcast.NewParser().ParseFeed(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return podcast
+}
+
+func jsonStr(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestFeedWriter(t *testing.T) {
+	for _, file := range []string{"test-feed-all.xml", "test-feed-atom.xml", "test-feed-rdf.xml", "test-feed-unknown.xml"} {
+		t.Run(file, func(t *testing.T) {
+			assertFeedWriterMatches(t, parseTestFeed(t, path.Join("testdata", file)))
+		})
+	}
+}
+
+func TestFeedWriter_TopPodcasts(t *testing.T) {
+	for _, file := range sampleTopPodcasts {
+		t.Run(file, func(t *testing.T) {
+			assertFeedWriterMatches(t, parseTestFeed(t, path.Join("testdata/top-podcasts", file)))
+		})
+	}
+}
+
+// assertFeedWriterMatches checks that writing the items of a parsed podcast
+// one at a time gives the same feed as WriteFeedXML.
+func assertFeedWriterMatches(t *testing.T, podcast *gopodcast.Podcast) {
+	t.Helper()
+	exp := &bytes.Buffer{}
+	if err := podcast.WriteFeedXML(exp); err != nil {
+		t.Fatal(err)
+	}
+
+	channel := *podcast
+	channel.Items = nil
+	actual := &bytes.Buffer{}
+	fw, err := gopodcast.NewFeedWriter(actual, &channel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range podcast.Items {
+		if err := fw.WriteItem(item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, exp.String(), actual.String())
+}
+
+func TestFeedWriter_ItemNamespaces(t *testing.T) {
+	podcast := &gopodcast.Podcast{
+		Title: "Test podcast 1",
+		Namespaces: []gopodcast.Namespace{
+			{Prefix: "dc", URI: "http://purl.org/dc/elements/1.1/"},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	fw, err := gopodcast.NewFeedWriter(buf, podcast)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fw.WriteItem(&gopodcast.Item{Title: "Test episode 1", DCCreator: "Test creator"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fw.WriteItem(&gopodcast.Item{Title: "Test episode 2", GooglePlayAuthor: "Test author"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	assertTrue(t, strings.Contains(out, `xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:dc="http://purl.org/dc/elements/1.1/"><channel>`))
+	assertTrue(t, strings.Contains(out, `<item><title>Test episode 1</title>`))
+	assertTrue(t, strings.Contains(out, `<dc:creator>Test creator</dc:creator></item>`))
+	assertTrue(t, strings.Contains(out, `<item xmlns:googleplay="http://www.google.com/schemas/play-podcasts/1.0"><title>Test episode 2</title>`))
+	assertTrue(t, strings.Contains(out, `<googleplay:author>Test author</googleplay:author></item>`))
+	assertTrue(t, strings.HasSuffix(out, `</channel></rss>`))
+
+	parsed, err := gopodcast.NewParser().ParseFeed(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertInt(t, 2, len(parsed.Items))
+	assertStr(t, "Test author", parsed.Items[1].GooglePlayAuthor)
+}
+
+func TestFeedWriter_WriteItems(t *testing.T) {
+	f, err := os.Open("testdata/test-feed-all.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	podcast, items, err := gopodcast.NewParser().ParseFeedStream(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	fw, err := gopodcast.NewFeedWriter(buf, podcast)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.WriteItems(items); err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	assertNotNil(t, fw.WriteItem(&gopodcast.Item{Title: "Test episode 3"}))
+
+	exp := parseTestFeed(t, "testdata/test-feed-all.xml")
+	actual, err := gopodcast.NewParser().ParseFeed(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStr(t, jsonStr(t, exp.Items), jsonStr(t, actual.Items))
+}
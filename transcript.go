@@ -0,0 +1,502 @@
+package gopodcast
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// The MIME types of transcript formats, see
+// https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/examples/transcripts/transcripts.md
+// All but plain text can be parsed by ParseTranscript, and all but HTML can
+// be written by Transcript.Write.
+const (
+	TranscriptTypeSRT   = "application/x-subrip"
+	TranscriptTypeVTT   = "text/vtt"
+	TranscriptTypeJSON  = "application/json"
+	TranscriptTypeHTML  = "text/html"
+	TranscriptTypePlain = "text/plain"
+)
+
+// Transcript is a transcript of an episode, as a list of cues in order.
+type Transcript struct {
+	Cues []TranscriptCue
+}
+
+// TranscriptCue is a section of a transcript. Speaker is empty if the format
+// doesn't name speakers, and End is the start of the next cue for formats
+// without end times.
+type TranscriptCue struct {
+	Start   time.Duration
+	End     time.Duration
+	Speaker string
+	Text    string
+}
+
+// ParseTranscript parses a transcript in the format given by its MIME type,
+// which is one of the TranscriptType constants, or "application/srt".
+func ParseTranscript(r io.Reader, mimeType string) (*Transcript, error) {
+	switch transcriptMediaType(mimeType) {
+	case TranscriptTypeSRT, "application/srt":
+		return parseSRT(r)
+	case TranscriptTypeVTT:
+		return parseVTT(r)
+	case TranscriptTypeJSON:
+		return parseJSONTranscript(r)
+	case TranscriptTypeHTML:
+		return parseHTMLTranscript(r)
+	}
+	return nil, fmt.Errorf("unsupported transcript type '%s'", mimeType)
+}
+
+// transcriptMediaType returns the media type of a MIME type, without any
+// parameters.
+func transcriptMediaType(mimeType string) string {
+	t, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(mimeType))
+	}
+	return t
+}
+
+// transcriptTypePreference is the order in which transcript formats are
+// preferred by PreferredTranscript, from most to least detailed.
+var transcriptTypePreference = []string{
+	TranscriptTypeJSON,
+	TranscriptTypeVTT,
+	TranscriptTypeSRT,
+	"application/srt",
+	TranscriptTypeHTML,
+}
+
+// PreferredTranscript returns the item's transcript in the given language,
+// such as "en" or "en-gb", or nil if it has none in a format supported by
+// ParseTranscript. A transcript in the same base language, or with no
+// language, is used if there isn't an exact match, but never one in another
+// language. When there is more than one the most detailed format is chosen.
+func (i *Item) PreferredTranscript(language string) *PodcastTranscript {
+	language = strings.ToLower(language)
+	base, _, _ := strings.Cut(language, "-")
+
+	var best *PodcastTranscript
+	bestScore := -1
+	for n := range i.PodcastTranscript {
+		t := &i.PodcastTranscript[n]
+		typeRank := slices.Index(transcriptTypePreference, transcriptMediaType(t.Type))
+		if typeRank < 0 {
+			continue
+		}
+
+		tLang := strings.ToLower(t.Language)
+		tBase, _, _ := strings.Cut(tLang, "-")
+		var langScore int
+		switch {
+		case tLang == language:
+			langScore = 3
+		case tBase == base:
+			langScore = 2
+		case tLang == "":
+			langScore = 1
+		default:
+			// never use a transcript in another language
+			continue
+		}
+
+		score := langScore*len(transcriptTypePreference) + len(transcriptTypePreference) - typeRank
+		if score > bestScore {
+			best, bestScore = t, score
+		}
+	}
+	return best
+}
+
+// FetchTranscript fetches and parses the item's preferred transcript in the
+// given language, see Item.PreferredTranscript, using the parser's http
+// client. The parser's auth credentials aren't sent, as the transcript's URL
+// comes from the feed and may be on any host.
+func (p *Parser) FetchTranscript(ctx context.Context, item *Item, language string) (tr *Transcript, err error) {
+	t := item.PreferredTranscript(language)
+	if t == nil {
+		return nil, fmt.Errorf("no supported transcript for language '%s'", language)
+	}
+
+	res, err := p.get(ctx, t.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		errVal := res.Body.Close()
+		if errVal != nil {
+			err = errVal
+		}
+	}()
+
+	return ParseTranscript(res.Body, t.Type)
+}
+
+var cueTimingRegexp = regexp.MustCompile(`^\s*(\S+)\s*-->\s*(\S+)`)
+
+// parseSRT parses a SubRip transcript. Cues are numbered blocks separated by
+// blank lines, with a timing line such as "00:00:01,000 --> 00:00:02,500".
+func parseSRT(r io.Reader) (*Transcript, error) {
+	blocks, err := transcriptBlocks(r)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := &Transcript{}
+	for _, block := range blocks {
+		// the timing line follows the cue number, which is sometimes missing
+		for i, line := range block {
+			if !strings.Contains(line, "-->") {
+				continue
+			}
+			cue, err := parseCueTiming(line)
+			if err != nil {
+				return nil, err
+			}
+			cue.Text = strings.Join(block[i+1:], "\n")
+			tr.Cues = append(tr.Cues, cue)
+			break
+		}
+	}
+	return tr, nil
+}
+
+var (
+	vttVoiceRegexp = regexp.MustCompile(`^<v(?:\.[^\s>]*)?\s+([^>]*)>`)
+	vttTagRegexp   = regexp.MustCompile(`</?[^>]*>`)
+)
+
+// parseVTT parses a WebVTT transcript. Speakers are taken from the voice tag
+// at the start of a cue's text, such as "<v Alice>", and other tags are
+// removed.
+func parseVTT(r io.Reader) (*Transcript, error) {
+	blocks, err := transcriptBlocks(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 || !strings.HasPrefix(blocks[0][0], "WEBVTT") {
+		return nil, fmt.Errorf("invalid vtt transcript, missing WEBVTT header")
+	}
+
+	tr := &Transcript{}
+	for _, block := range blocks[1:] {
+		// NOTE, STYLE and REGION blocks have no timing line
+		for i, line := range block {
+			if !strings.Contains(line, "-->") {
+				continue
+			}
+			cue, err := parseCueTiming(line)
+			if err != nil {
+				return nil, err
+			}
+			text := strings.Join(block[i+1:], "\n")
+			if m := vttVoiceRegexp.FindStringSubmatch(text); m != nil {
+				cue.Speaker = strings.TrimSpace(m[1])
+			}
+			cue.Text = unescapeHTML(vttTagRegexp.ReplaceAllString(text, ""))
+			tr.Cues = append(tr.Cues, cue)
+			break
+		}
+	}
+	return tr, nil
+}
+
+// transcriptBlocks reads the blocks of lines separated by blank lines used
+// by SRT and WebVTT.
+func transcriptBlocks(r io.Reader) ([][]string, error) {
+	var blocks [][]string
+	var block []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), "\r")
+		if len(blocks) == 0 && len(block) == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if strings.TrimSpace(line) == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+				block = nil
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+	return blocks, s.Err()
+}
+
+func parseCueTiming(line string) (TranscriptCue, error) {
+	m := cueTimingRegexp.FindStringSubmatch(line)
+	if m == nil {
+		return TranscriptCue{}, fmt.Errorf("invalid cue timing '%s'", line)
+	}
+	start, err := parseTranscriptTime(m[1])
+	if err != nil {
+		return TranscriptCue{}, err
+	}
+	end, err := parseTranscriptTime(m[2])
+	if err != nil {
+		return TranscriptCue{}, err
+	}
+	return TranscriptCue{Start: start, End: end}, nil
+}
+
+// parseTranscriptTime parses a time such as "01:02:03,500", "02:03.500" or
+// "2:03".
+func parseTranscriptTime(s string) (time.Duration, error) {
+	var t NormalPlayTime
+	if err := t.UnmarshalText([]byte(strings.Replace(s, ",", ".", 1))); err != nil {
+		return 0, fmt.Errorf("failed to parse transcript time '%s'", s)
+	}
+	return time.Duration(t), nil
+}
+
+// jsonTranscriptVersion is the version of the JSON transcript format written
+const jsonTranscriptVersion = "1.0.0"
+
+// jsonTranscript is the Podcasting 2.0 JSON transcript format
+type jsonTranscript struct {
+	Version  string                  `json:"version"`
+	Segments []jsonTranscriptSegment `json:"segments"`
+}
+
+type jsonTranscriptSegment struct {
+	Speaker   string  `json:"speaker,omitempty"`
+	StartTime float64 `json:"startTime"`
+	EndTime   float64 `json:"endTime"`
+	Body      string  `json:"body"`
+}
+
+func parseJSONTranscript(r io.Reader) (*Transcript, error) {
+	var jt jsonTranscript
+	if err := json.NewDecoder(r).Decode(&jt); err != nil {
+		return nil, err
+	}
+
+	tr := &Transcript{}
+	for _, s := range jt.Segments {
+		tr.Cues = append(tr.Cues, TranscriptCue{
+			Start:   secondsToDuration(s.StartTime),
+			End:     secondsToDuration(s.EndTime),
+			Speaker: s.Speaker,
+			Text:    s.Body,
+		})
+	}
+	return tr, nil
+}
+
+func secondsToDuration(secs float64) time.Duration {
+	return time.Duration(secs*1000+0.5) * time.Millisecond
+}
+
+// parseHTMLTranscript parses an HTML transcript, where each paragraph is a
+// cue, preceded by a cite element naming the speaker and a time element
+// with its start time, e.g.
+//
+//	<cite>Alice:</cite>
+//	<time>0:00</time>
+//	<p>Hello</p>
+//
+// The speaker and time carry over to later paragraphs if not repeated.
+func parseHTMLTranscript(r io.Reader) (*Transcript, error) {
+	d := xml.NewDecoder(r)
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	tr := &Transcript{}
+	var speaker string
+	var start time.Duration
+	var text strings.Builder
+	var inElement string
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := strings.ToLower(t.Name.Local)
+			switch {
+			case inElement == "" && (name == "cite" || name == "time" || name == "p"):
+				inElement = name
+				text.Reset()
+			case inElement == "p" && name == "br":
+				text.WriteString("\n")
+			}
+		case xml.EndElement:
+			if strings.ToLower(t.Name.Local) != inElement {
+				continue
+			}
+			s := strings.TrimSpace(text.String())
+			switch inElement {
+			case "cite":
+				speaker = strings.TrimSpace(strings.TrimSuffix(s, ":"))
+			case "time":
+				if start, err = parseTranscriptTime(s); err != nil {
+					return nil, err
+				}
+			case "p":
+				if s != "" {
+					tr.Cues = append(tr.Cues, TranscriptCue{Start: start, Speaker: speaker, Text: s})
+				}
+			}
+			inElement = ""
+		case xml.CharData:
+			if inElement != "" {
+				text.Write(t)
+			}
+		}
+	}
+
+	for i := range tr.Cues {
+		if i+1 < len(tr.Cues) {
+			tr.Cues[i].End = tr.Cues[i+1].Start
+		} else {
+			tr.Cues[i].End = tr.Cues[i].Start
+		}
+	}
+	return tr, nil
+}
+
+var htmlUnescaper = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">", "&quot;", `"`, "&#39;", "'", "&nbsp;", " ", "&lrm;", "\u200e", "&rlm;", "\u200f")
+
+func unescapeHTML(s string) string {
+	return htmlUnescaper.Replace(s)
+}
+
+// isTimedTranscriptType reports whether transcripts of the given MIME type
+// have cue times, and so can be used as captions.
+func isTimedTranscriptType(mimeType string) bool {
+	switch transcriptMediaType(mimeType) {
+	case TranscriptTypeSRT, "application/srt", TranscriptTypeVTT, TranscriptTypeJSON:
+		return true
+	}
+	return false
+}
+
+// AddTranscript adds a podcast:transcript to the item for a transcript
+// written in the format of the given MIME type, such as TranscriptTypeVTT.
+// Transcripts with cue times are added with rel="captions". If the item
+// already has a transcript with the same URL, it is replaced.
+func (i *Item) AddTranscript(url, mimeType, language string) {
+	t := PodcastTranscript{URL: url, Type: mimeType, Language: language}
+	if isTimedTranscriptType(mimeType) {
+		t.Rel = "captions"
+	}
+	for n := range i.PodcastTranscript {
+		if i.PodcastTranscript[n].URL == url {
+			i.PodcastTranscript[n] = t
+			return
+		}
+	}
+	i.PodcastTranscript = append(i.PodcastTranscript, t)
+}
+
+// Write writes the transcript in the format of the given MIME type, which is
+// one of the TranscriptType constants other than TranscriptTypeHTML, or
+// "application/srt". Speakers are written as WebVTT voice tags, in the
+// speaker field of JSON, and as a "Speaker: " prefix in plain text. SRT has
+// no way to name speakers, so they are left out.
+func (t *Transcript) Write(w io.Writer, mimeType string) error {
+	switch transcriptMediaType(mimeType) {
+	case TranscriptTypeSRT, "application/srt":
+		return t.writeSRT(w)
+	case TranscriptTypeVTT:
+		return t.writeVTT(w)
+	case TranscriptTypeJSON:
+		return t.writeJSON(w)
+	case TranscriptTypePlain:
+		return t.writePlain(w)
+	}
+	return fmt.Errorf("unsupported transcript type '%s'", mimeType)
+}
+
+func (t *Transcript) writeSRT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for n, cue := range t.Cues {
+		if n > 0 {
+			bw.WriteString("\n")
+		}
+		fmt.Fprintf(bw, "%d\n%s --> %s\n%s\n",
+			n+1, formatTranscriptTime(cue.Start, ","), formatTranscriptTime(cue.End, ","), cueText(cue.Text))
+	}
+	return bw.Flush()
+}
+
+var vttEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func (t *Transcript) writeVTT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("WEBVTT\n")
+	for _, cue := range t.Cues {
+		text := vttEscaper.Replace(cueText(cue.Text))
+		if cue.Speaker != "" {
+			text = "<v " + vttEscaper.Replace(cue.Speaker) + ">" + text
+		}
+		fmt.Fprintf(bw, "\n%s --> %s\n%s\n",
+			formatTranscriptTime(cue.Start, "."), formatTranscriptTime(cue.End, "."), text)
+	}
+	return bw.Flush()
+}
+
+func (t *Transcript) writeJSON(w io.Writer) error {
+	jt := jsonTranscript{Version: jsonTranscriptVersion, Segments: []jsonTranscriptSegment{}}
+	for _, cue := range t.Cues {
+		jt.Segments = append(jt.Segments, jsonTranscriptSegment{
+			Speaker:   cue.Speaker,
+			StartTime: cue.Start.Seconds(),
+			EndTime:   cue.End.Seconds(),
+			Body:      cue.Text,
+		})
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	e.SetEscapeHTML(false)
+	return e.Encode(jt)
+}
+
+func (t *Transcript) writePlain(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, cue := range t.Cues {
+		if cue.Speaker != "" {
+			bw.WriteString(cue.Speaker + ": ")
+		}
+		bw.WriteString(cue.Text + "\n")
+	}
+	return bw.Flush()
+}
+
+// formatTranscriptTime formats a cue time as "01:02:03.500", with the given
+// separator before the milliseconds.
+func formatTranscriptTime(d time.Duration, sep string) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+// cueText removes blank lines from the text of a cue, which would end the cue
+// early in SRT and WebVTT.
+func cueText(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
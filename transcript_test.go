@@ -0,0 +1,317 @@
+package gopodcast_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/webbgeorge/gopodcast"
+)
+
+func TestParseTranscript_SRT(t *testing.T) {
+	srt := "1\r\n00:00:00,000 --> 00:00:02,500\r\nHello and welcome\r\nto the show\r\n\r\n2\r\n00:00:02,500 --> 00:01:05,000\r\nThanks\r\n"
+
+	tr, err := gopodcast.ParseTranscript(strings.NewReader(srt), "application/x-subrip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertInt(t, 2, len(tr.Cues))
+	assertCue(t, gopodcast.TranscriptCue{End: 2500 * time.Millisecond, Text: "Hello and welcome\nto the show"}, tr.Cues[0])
+	assertCue(t, gopodcast.TranscriptCue{Start: 2500 * time.Millisecond, End: 65 * time.Second, Text: "Thanks"}, tr.Cues[1])
+}
+
+func TestParseTranscript_VTT(t *testing.T) {
+	vtt := `WEBVTT
+
+NOTE a comment
+
+intro
+00:00.000 --> 00:02.500 align:start
+<v Alice>Hello &amp; <b>welcome</b>
+
+01:00:02.500 --> 01:00:05.000
+<v.loud Bob>Thanks</v>
+`
+
+	tr, err := gopodcast.ParseTranscript(strings.NewReader(vtt), "text/vtt; charset=utf-8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertInt(t, 2, len(tr.Cues))
+	assertCue(t, gopodcast.TranscriptCue{End: 2500 * time.Millisecond, Speaker: "Alice", Text: "Hello & welcome"}, tr.Cues[0])
+	assertCue(t, gopodcast.TranscriptCue{Start: time.Hour + 2500*time.Millisecond, End: time.Hour + 5*time.Second, Speaker: "Bob", Text: "Thanks"}, tr.Cues[1])
+}
+
+func TestParseTranscript_VTTMissingHeader(t *testing.T) {
+	_, err := gopodcast.ParseTranscript(strings.NewReader("00:00.000 --> 00:02.500\nHello"), "text/vtt")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	assertStr(t, "invalid vtt transcript, missing WEBVTT header", err.Error())
+}
+
+func TestParseTranscript_JSON(t *testing.T) {
+	js := `{
+  "version": "1.0.0",
+  "segments": [
+    {"speaker": "Alice", "startTime": 0, "endTime": 2.5, "body": "Hello"},
+    {"startTime": 2.5, "endTime": 5.123, "body": "Thanks"}
+  ]
+}`
+
+	tr, err := gopodcast.ParseTranscript(strings.NewReader(js), "application/json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertInt(t, 2, len(tr.Cues))
+	assertCue(t, gopodcast.TranscriptCue{End: 2500 * time.Millisecond, Speaker: "Alice", Text: "Hello"}, tr.Cues[0])
+	assertCue(t, gopodcast.TranscriptCue{Start: 2500 * time.Millisecond, End: 5123 * time.Millisecond, Text: "Thanks"}, tr.Cues[1])
+}
+
+func TestParseTranscript_HTML(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<body>
+<cite>Alice:</cite>
+<time>0:00</time>
+<p>Hello &amp; welcome<br>to the show</p>
+<time>0:02</time>
+<p>Still Alice</p>
+<cite>Bob:</cite>
+<time>1:00:05</time>
+<p>Thanks</p>
+</body>
+</html>`
+
+	tr, err := gopodcast.ParseTranscript(strings.NewReader(html), "text/html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertInt(t, 3, len(tr.Cues))
+	assertCue(t, gopodcast.TranscriptCue{End: 2 * time.Second, Speaker: "Alice", Text: "Hello & welcome\nto the show"}, tr.Cues[0])
+	assertCue(t, gopodcast.TranscriptCue{Start: 2 * time.Second, End: time.Hour + 5*time.Second, Speaker: "Alice", Text: "Still Alice"}, tr.Cues[1])
+	assertCue(t, gopodcast.TranscriptCue{Start: time.Hour + 5*time.Second, End: time.Hour + 5*time.Second, Speaker: "Bob", Text: "Thanks"}, tr.Cues[2])
+}
+
+func TestParseTranscript_UnsupportedType(t *testing.T) {
+	_, err := gopodcast.ParseTranscript(strings.NewReader("Hello"), "text/plain")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	assertStr(t, "unsupported transcript type 'text/plain'", err.Error())
+}
+
+func TestItem_PreferredTranscript(t *testing.T) {
+	item := &gopodcast.Item{
+		PodcastTranscript: []gopodcast.PodcastTranscript{
+			{URL: "https://www.example.com/en.txt", Type: "text/plain", Language: "en"},
+			{URL: "https://www.example.com/en.html", Type: "text/html", Language: "en"},
+			{URL: "https://www.example.com/en-us.srt", Type: "application/x-subrip", Language: "en-US"},
+			{URL: "https://www.example.com/fr.json", Type: "application/json", Language: "fr"},
+			{URL: "https://www.example.com/any.vtt", Type: "text/vtt"},
+		},
+	}
+
+	assertStr(t, "https://www.example.com/en-us.srt", item.PreferredTranscript("en-us").URL)
+	assertStr(t, "https://www.example.com/en-us.srt", item.PreferredTranscript("en-GB").URL)
+	assertStr(t, "https://www.example.com/en.html", item.PreferredTranscript("en").URL)
+	assertStr(t, "https://www.example.com/fr.json", item.PreferredTranscript("fr").URL)
+	assertStr(t, "https://www.example.com/any.vtt", item.PreferredTranscript("de").URL)
+	assertNil(t, (&gopodcast.Item{}).PreferredTranscript("en"))
+
+	// transcripts in other languages aren't used
+	item = &gopodcast.Item{
+		PodcastTranscript: []gopodcast.PodcastTranscript{
+			{URL: "https://www.example.com/fr.vtt", Type: "text/vtt", Language: "fr"},
+		},
+	}
+	assertNil(t, item.PreferredTranscript("en"))
+	assertStr(t, "https://www.example.com/fr.vtt", item.PreferredTranscript("fr-CA").URL)
+}
+
+func TestFetchTranscript(t *testing.T) {
+	item := &gopodcast.Item{
+		PodcastTranscript: []gopodcast.PodcastTranscript{
+			{URL: "https://www.example.com/en.vtt", Type: "text/vtt", Language: "en"},
+		},
+	}
+
+	parser := gopodcast.NewParser()
+	parser.HTTPClient = newTestClient(200, "WEBVTT\n\n00:00.000 --> 00:01.000\n<v Alice>Hello\n")
+
+	tr, err := parser.FetchTranscript(context.Background(), item, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertInt(t, 1, len(tr.Cues))
+	assertStr(t, "Alice", tr.Cues[0].Speaker)
+
+	_, err = parser.FetchTranscript(context.Background(), &gopodcast.Item{}, "en")
+	assertStr(t, "no supported transcript for language 'en'", err.Error())
+
+	parser.HTTPClient = newTestClient(404, "not found")
+	_, err = parser.FetchTranscript(context.Background(), item, "en")
+	assertStr(t, "non-200 http response '404'", err.Error())
+}
+
+func TestFetchTranscript_AuthCredentials(t *testing.T) {
+	item := &gopodcast.Item{
+		PodcastTranscript: []gopodcast.PodcastTranscript{
+			{URL: "https://evil.example/en.vtt", Type: "text/vtt", Language: "en"},
+		},
+	}
+	transport := &routeTransport{
+		routes:      map[string]testRoute{"https://evil.example/en.vtt": {status: 200, body: "WEBVTT\n\n00:00.000 --> 00:01.000\nHello\n"}},
+		authHeaders: make(map[string]string),
+	}
+
+	parser := gopodcast.NewParser()
+	parser.HTTPClient = &http.Client{Transport: transport}
+	parser.AuthCredentials = &gopodcast.AuthCredentials{Username: "user1", Password: "password1"}
+
+	tr, err := parser.FetchTranscript(context.Background(), item, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertInt(t, 1, len(tr.Cues))
+	// credentials aren't sent to hosts named by the feed
+	_, requested := transport.authHeaders["https://evil.example/en.vtt"]
+	assertTrue(t, requested)
+	assertStr(t, "", transport.authHeaders["https://evil.example/en.vtt"])
+}
+
+func assertCue(t *testing.T, exp, act gopodcast.TranscriptCue) {
+	t.Helper()
+	if exp != act {
+		t.Fatalf("expected %+v, got %+v", exp, act)
+	}
+}
+
+var testTranscript = &gopodcast.Transcript{
+	Cues: []gopodcast.TranscriptCue{
+		{End: 2500 * time.Millisecond, Speaker: "Alice", Text: "Hello & welcome\n\nto the show"},
+		{Start: 2500 * time.Millisecond, End: time.Hour + 5*time.Second, Text: "Thanks"},
+	},
+}
+
+func TestTranscriptWrite_SRT(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := testTranscript.Write(buf, gopodcast.TranscriptTypeSRT); err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, `1
+00:00:00,000 --> 00:00:02,500
+Hello & welcome
+to the show
+
+2
+00:00:02,500 --> 01:00:05,000
+Thanks
+`, buf.String())
+
+	tr, err := gopodcast.ParseTranscript(buf, gopodcast.TranscriptTypeSRT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertCue(t, gopodcast.TranscriptCue{Start: 2500 * time.Millisecond, End: time.Hour + 5*time.Second, Text: "Thanks"}, tr.Cues[1])
+}
+
+func TestTranscriptWrite_VTT(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := testTranscript.Write(buf, gopodcast.TranscriptTypeVTT); err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, `WEBVTT
+
+00:00:00.000 --> 00:00:02.500
+<v Alice>Hello &amp; welcome
+to the show
+
+00:00:02.500 --> 01:00:05.000
+Thanks
+`, buf.String())
+
+	tr, err := gopodcast.ParseTranscript(buf, gopodcast.TranscriptTypeVTT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertCue(t, gopodcast.TranscriptCue{End: 2500 * time.Millisecond, Speaker: "Alice", Text: "Hello & welcome\nto the show"}, tr.Cues[0])
+}
+
+func TestTranscriptWrite_JSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := testTranscript.Write(buf, gopodcast.TranscriptTypeJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, `{
+  "version": "1.0.0",
+  "segments": [
+    {
+      "speaker": "Alice",
+      "startTime": 0,
+      "endTime": 2.5,
+      "body": "Hello & welcome\n\nto the show"
+    },
+    {
+      "startTime": 2.5,
+      "endTime": 3605,
+      "body": "Thanks"
+    }
+  ]
+}
+`, buf.String())
+
+	tr, err := gopodcast.ParseTranscript(buf, gopodcast.TranscriptTypeJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertCue(t, testTranscript.Cues[0], tr.Cues[0])
+	assertCue(t, testTranscript.Cues[1], tr.Cues[1])
+}
+
+func TestTranscriptWrite_Plain(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := testTranscript.Write(buf, gopodcast.TranscriptTypePlain); err != nil {
+		t.Fatal(err)
+	}
+
+	assertStr(t, "Alice: Hello & welcome\n\nto the show\nThanks\n", buf.String())
+}
+
+func TestTranscriptWrite_UnsupportedType(t *testing.T) {
+	err := testTranscript.Write(&bytes.Buffer{}, gopodcast.TranscriptTypeHTML)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	assertStr(t, "unsupported transcript type 'text/html'", err.Error())
+}
+
+func TestItem_AddTranscript(t *testing.T) {
+	item := &gopodcast.Item{}
+	item.AddTranscript("https://www.example.com/ep-1.vtt", gopodcast.TranscriptTypeVTT, "en")
+	item.AddTranscript("https://www.example.com/ep-1.json", gopodcast.TranscriptTypeJSON, "en")
+	item.AddTranscript("https://www.example.com/ep-1.txt", gopodcast.TranscriptTypePlain, "en")
+	item.AddTranscript("https://www.example.com/ep-1.vtt", gopodcast.TranscriptTypeVTT, "en-gb")
+
+	assertInt(t, 3, len(item.PodcastTranscript))
+	exp := []gopodcast.PodcastTranscript{
+		{URL: "https://www.example.com/ep-1.vtt", Type: "text/vtt", Rel: "captions", Language: "en-gb"},
+		{URL: "https://www.example.com/ep-1.json", Type: "application/json", Rel: "captions", Language: "en"},
+		{URL: "https://www.example.com/ep-1.txt", Type: "text/plain", Language: "en"},
+	}
+	for i := range exp {
+		if exp[i] != item.PodcastTranscript[i] {
+			t.Fatalf("expected %+v, got %+v", exp[i], item.PodcastTranscript[i])
+		}
+	}
+}
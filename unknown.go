@@ -0,0 +1,247 @@
+package gopodcast
+
+import (
+	"encoding/xml"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// UnknownElement is an XML element which isn't otherwise modelled by this
+// package. Unknown elements are kept when parsing a feed so that they can be
+// written back out by WriteFeedXML.
+type UnknownElement struct {
+	// XMLName.Space is the namespace URI of the element
+	XMLName  xml.Name         `json:"xmlName"`
+	Attrs    []xml.Attr       `xml:",any,attr" json:"attrs,omitempty"`
+	Text     string           `xml:",chardata" json:"text,omitempty"`
+	Children []UnknownElement `xml:",any" json:"children,omitempty"`
+
+	// After is the name of the known element which came before this element
+	// in the parsed feed, used to write it back out in the same position. If
+	// empty, the element is written first.
+	After xml.Name `xml:"-" json:"after"`
+}
+
+func (u *UnknownElement) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type unknownElement UnknownElement
+	var v unknownElement
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	*u = UnknownElement(v)
+	// restore the name of an element renamed by elementRecorder
+	u.XMLName.Local = strings.TrimPrefix(u.XMLName.Local, unmatchedElementPrefix)
+	return nil
+}
+
+// unmatchedElementPrefix is added to the names of elements by
+// elementRecorder so that they aren't decoded into fields for unprefixed
+// elements, since encoding/xml matches those in any namespace.
+const unmatchedElementPrefix = "\x00"
+
+// knownElements are the elements decoded by the structs of a feed format:
+// those of the channel and of each item, and those anywhere in the feed.
+// Namespaced elements which aren't known, but share their name with an
+// unprefixed element, are renamed by elementRecorder so that e.g. <foo:title>
+// is kept as an unknown element rather than decoded as <title>.
+type knownElements struct {
+	channel map[xml.Name]bool
+	item    map[xml.Name]bool
+	all     map[xml.Name]bool
+}
+
+func newKnownElements(feed, channel, item reflect.Type) *knownElements {
+	k := &knownElements{
+		channel: make(map[xml.Name]bool),
+		item:    make(map[xml.Name]bool),
+		all:     make(map[xml.Name]bool),
+	}
+	collectElementNames(channel, k.channel, nil)
+	collectElementNames(item, k.item, nil)
+	collectElementNames(feed, k.all, make(map[reflect.Type]bool))
+	return k
+}
+
+// collectElementNames adds the element names of the fields of t, a struct
+// type, to names, including those of embedded structs. If seen is set, the
+// names of the fields of nested structs are added too.
+func collectElementNames(t reflect.Type, names map[xml.Name]bool, seen map[reflect.Type]bool) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return
+	}
+	if seen != nil {
+		seen[t] = true
+	}
+
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("xml")
+		if f.Anonymous && tag == "" {
+			// embedded fields are visited by reflect.VisibleFields
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		if name == "-" || strings.Contains(name, ">") || !isElementField(flags) {
+			continue
+		}
+		if space, local, ok := strings.Cut(name, " "); ok {
+			names[xml.Name{Space: space, Local: local}] = true
+		} else if name != "" {
+			names[xml.Name{Local: name}] = true
+		}
+		if seen != nil {
+			collectElementNames(f.Type, names, seen)
+		}
+	}
+}
+
+// isElementField returns whether a field with the given xml tag flags
+// decodes child elements.
+func isElementField(flags string) bool {
+	for _, flag := range strings.Split(flags, ",") {
+		switch flag {
+		case "attr", "chardata", "cdata", "innerxml", "comment", "any":
+			return false
+		}
+	}
+	return true
+}
+
+// elementRecorder is an xml.TokenReader which records the order of the child
+// elements of the channel and of each item, and any namespace declarations,
+// as a feed is decoded. Variations of the URLs of builtin namespaces are
+// replaced with the URL used in struct tags, and namespaced elements which
+// aren't known are renamed, see knownElements.
+type elementRecorder struct {
+	d          xml.TokenReader
+	depth      int
+	open       []xml.Name
+	inItem     bool
+	channel    []xml.Name
+	items      [][]xml.Name
+	namespaces []Namespace
+
+	// channelDepth is the depth of the channel's child elements, and
+	// itemName the name of its item elements, which differ between formats
+	channelDepth int
+	itemName     string
+
+	// known are the elements decoded by the feed format, and feedSpaces the
+	// namespaces of the format, along with that of the root element, whose
+	// elements are never renamed
+	known      *knownElements
+	feedSpaces []string
+}
+
+func newElementRecorder(d xml.TokenReader) *elementRecorder {
+	return &elementRecorder{d: d, channelDepth: 3, itemName: "item"}
+}
+
+func (r *elementRecorder) Token() (xml.Token, error) {
+	tok, err := r.d.Token()
+	switch t := tok.(type) {
+	case xml.StartElement:
+		r.depth++
+		r.recordNamespaces(t)
+		t.Name.Space = canonicalNamespace(t.Name.Space)
+		for i, a := range t.Attr {
+			if !isNamespaceDecl(a) {
+				t.Attr[i].Name.Space = canonicalNamespace(a.Name.Space)
+			}
+		}
+		name := t.Name
+		if r.depth == 1 {
+			r.feedSpaces = append(r.feedSpaces, name.Space)
+		}
+		if !r.isKnown(name) {
+			t.Name.Local = unmatchedElementPrefix + name.Local
+		}
+		r.open = append(r.open, t.Name)
+		tok = t
+		switch {
+		// rss > channel > item
+		case r.depth == r.channelDepth && t.Name.Local == r.itemName:
+			r.inItem = true
+			r.items = append(r.items, nil)
+			r.channel = append(r.channel, name)
+		case r.depth == r.channelDepth:
+			r.channel = append(r.channel, name)
+		case r.depth == r.channelDepth+1 && r.inItem:
+			r.items[len(r.items)-1] = append(r.items[len(r.items)-1], name)
+		}
+	case xml.EndElement:
+		if r.depth == r.channelDepth {
+			r.inItem = false
+		}
+		r.depth--
+		t.Name.Space = canonicalNamespace(t.Name.Space)
+		if len(r.open) > 0 {
+			// the start element may have been renamed
+			t.Name = r.open[len(r.open)-1]
+			r.open = r.open[:len(r.open)-1]
+		}
+		tok = t
+	}
+	return tok, err
+}
+
+// isKnown returns whether the element started at the current depth is in
+// one of the feed's namespaces, is decoded by a field of the struct it is
+// decoded into, or can't be mistaken for an unprefixed element.
+func (r *elementRecorder) isKnown(name xml.Name) bool {
+	if r.known == nil || name.Space == "" || slices.Contains(r.feedSpaces, name.Space) ||
+		!r.known.all[xml.Name{Local: name.Local}] {
+		return true
+	}
+	switch {
+	case r.depth == r.channelDepth:
+		return r.known.channel[name]
+	case r.depth == r.channelDepth+1 && r.inItem:
+		return r.known.item[name]
+	}
+	return r.known.all[name]
+}
+
+func (r *elementRecorder) recordNamespaces(start xml.StartElement) {
+	for _, a := range start.Attr {
+		if a.Name.Space != "xmlns" {
+			continue
+		}
+		if !hasNamespacePrefix(r.namespaces, a.Name.Local) {
+			r.namespaces = append(r.namespaces, Namespace{Prefix: a.Name.Local, URI: a.Value})
+		}
+	}
+}
+
+// apply sets the position of the podcast's unknown elements, and the
+// namespaces declared in the feed.
+func (r *elementRecorder) apply(pc *Podcast) {
+	pc.Namespaces = r.namespaces
+	anchorUnknownElements(pc.UnknownElements, r.channel)
+	for i, item := range pc.Items {
+		if i < len(r.items) {
+			anchorUnknownElements(item.UnknownElements, r.items[i])
+		}
+	}
+}
+
+// anchorUnknownElements sets After for each unknown element, given the names
+// of all sibling elements in order.
+func anchorUnknownElements(unknown []UnknownElement, siblings []xml.Name) {
+	var last xml.Name
+	n := 0
+	for _, name := range siblings {
+		if n < len(unknown) && unknown[n].XMLName == name {
+			unknown[n].After = last
+			n++
+			continue
+		}
+		last = name
+	}
+}
@@ -2,6 +2,7 @@ package gopodcast
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -51,6 +52,137 @@ func unmarshalBoolLike(text []byte) bool {
 	}
 }
 
+// Explicit is a parental advisory value, as used by googleplay:explicit,
+// which is "yes", "no" or "clean". The text is kept as it was parsed, so that
+// it is written back unchanged.
+type Explicit string
+
+const (
+	ExplicitYes   Explicit = "yes"
+	ExplicitNo    Explicit = "no"
+	ExplicitClean Explicit = "clean"
+)
+
+// IsExplicit returns whether the value marks content as explicit, such as
+// "yes" or "true".
+func (e Explicit) IsExplicit() bool {
+	return unmarshalBoolLike([]byte(strings.TrimSpace(string(e))))
+}
+
+// Keywords is a list of keywords which unmarshals from, and marshals to, a
+// comma separated list. Keywords are trimmed, and duplicates are removed
+// ignoring case, keeping the first.
+type Keywords []string
+
+func (k *Keywords) UnmarshalText(text []byte) error {
+	*k = Keywords(strings.Split(string(text), ",")).normalize()
+	return nil
+}
+
+func (k Keywords) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(k.normalize(), ",")), nil
+}
+
+func (k Keywords) normalize() Keywords {
+	var r Keywords
+	seen := make(map[string]bool)
+	for _, kw := range k {
+		kw = strings.TrimSpace(kw)
+		if kw == "" || seen[strings.ToLower(kw)] {
+			continue
+		}
+		seen[strings.ToLower(kw)] = true
+		r = append(r, kw)
+	}
+	return r
+}
+
+// Int is an integer which keeps the text it was parsed from, so that a
+// malformed value doesn't stop a feed from being parsed and is written back
+// as it was. Valid values are written in their canonical form, e.g. " 03 "
+// is written as "3". Use Value to get the integer, and NewInt to create one.
+type Int string
+
+func NewInt(n int) Int {
+	return Int(strconv.Itoa(n))
+}
+
+// Value returns the integer, with ok false if the text isn't an integer.
+func (i Int) Value() (n int, ok bool) {
+	n, err := strconv.Atoi(strings.TrimSpace(string(i)))
+	return n, err == nil
+}
+
+func (i Int) MarshalText() ([]byte, error) {
+	if n, ok := i.Value(); ok {
+		return []byte(strconv.Itoa(n)), nil
+	}
+	return []byte(i), nil
+}
+
+// Date is a date which keeps the text it was parsed from, so that a malformed
+// date doesn't stop a feed from being parsed, and is written back as it was.
+// Use Value to get the time, which accepts the same formats as Time, and
+// NewDate to create one.
+type Date string
+
+func NewDate(t time.Time) Date {
+	return Date(t.Format(time.RFC1123))
+}
+
+// Value returns the time, with ok false if the text isn't a supported date.
+func (d Date) Value() (t time.Time, ok bool) {
+	var tt Time
+	if err := tt.UnmarshalText([]byte(strings.TrimSpace(string(d)))); err != nil {
+		return time.Time{}, false
+	}
+	return time.Time(tt), true
+}
+
+// NewISO8601Date returns a Date in ISO 8601 format, as used by dc:date and
+// sy:updateBase, rather than the RFC 1123 format returned by NewDate.
+func NewISO8601Date(t time.Time) Date {
+	return Date(t.Format(time.RFC3339))
+}
+
+// CountryCodes is a list of ISO 3166-1 alpha-2 country codes which
+// unmarshals from, and marshals to, a space separated list, as used by
+// spotify:countryOfOrigin. Codes are lower case. Unknown codes are kept, so
+// that a parsed feed is written as it was, and are reported by Validate.
+type CountryCodes []string
+
+func (c *CountryCodes) UnmarshalText(text []byte) error {
+	*c = CountryCodes(strings.Fields(string(text))).normalize()
+	return nil
+}
+
+// Validate returns an error for the first code which isn't an ISO 3166-1
+// alpha-2 country code.
+func (c CountryCodes) Validate() error {
+	for _, code := range c {
+		if !IsCountryCode(code) {
+			return fmt.Errorf("invalid country code '%s'", code)
+		}
+	}
+	return nil
+}
+
+func (c CountryCodes) normalize() CountryCodes {
+	var codes CountryCodes
+	for _, code := range c {
+		codes = append(codes, strings.ToLower(code))
+	}
+	return codes
+}
+
+func (c CountryCodes) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(c.normalize(), " ")), nil
+}
+
+// Time is an alias for `time.Time` which unmarshals from the RFC 1123 dates
+// used by RSS, or the ISO 8601 dates used by Dublin Core, and marshals to
+// RFC 1123. Times in the Dublin Core and syndication namespaces, such as
+// Item.DCDate, are written in ISO 8601 format instead, as they require.
 type Time time.Time
 
 func (t *Time) UnmarshalText(text []byte) error {
@@ -61,19 +193,78 @@ func (t *Time) UnmarshalText(text []byte) error {
 		time.RFC1123Z,
 		"Mon, _2 Jan 2006 15:04:05 MST",   // 1123, with _2
 		"Mon, _2 Jan 2006 15:04:05 -0700", // 1123Z, with _2
+		time.RFC3339,
+		"2006-01-02T15:04Z07:00",
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+		"2006-01-02 15:04:05Z07:00",
+		"2006-01-02 15:04:05",
+		"2006-01-02",
+		"2006-01",
+		"2006",
 	}
+	s := strings.TrimSpace(string(text))
 	var tt time.Time
 	var err error
 	for _, f := range formats {
-		tt, err = time.Parse(f, string(text))
+		tt, err = time.Parse(f, s)
 		if err == nil {
 			*t = Time(tt)
 			return nil
 		}
 	}
-	return fmt.Errorf("failed to parse time '%s'", string(text))
+	return fmt.Errorf("failed to parse time '%s'", s)
 }
 
 func (t Time) MarshalText() ([]byte, error) {
 	return []byte(time.Time(t).Format(time.RFC1123)), nil
 }
+
+func (t Time) marshalISO8601() string {
+	return time.Time(t).Format(time.RFC3339)
+}
+
+// NormalPlayTime is an alias for `time.Duration` which unmarshals from, and
+// marshals to, the normal play time format used by Podlove Simple Chapters,
+// such as "01:02:03.500", "02:03" or "3".
+type NormalPlayTime time.Duration
+
+func (t *NormalPlayTime) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	parts := strings.Split(s, ":")
+	if s == "" || len(parts) > 3 {
+		return fmt.Errorf("failed to parse normal play time '%s'", s)
+	}
+
+	secs, frac, _ := strings.Cut(parts[len(parts)-1], ".")
+	var d time.Duration
+	for _, p := range append(parts[:len(parts)-1], secs) {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return fmt.Errorf("failed to parse normal play time '%s'", s)
+		}
+		d = d*60 + time.Duration(n)*time.Second
+	}
+	if frac != "" {
+		if len(frac) > 9 {
+			frac = frac[:9]
+		}
+		n, err := strconv.Atoi(frac + strings.Repeat("0", 9-len(frac)))
+		if err != nil || n < 0 {
+			return fmt.Errorf("failed to parse normal play time '%s'", s)
+		}
+		d += time.Duration(n)
+	}
+
+	*t = NormalPlayTime(d)
+	return nil
+}
+
+func (t NormalPlayTime) MarshalText() ([]byte, error) {
+	d := time.Duration(t)
+	s := fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+	if ms := d.Milliseconds() % 1000; ms != 0 {
+		s += fmt.Sprintf(".%03d", ms)
+	}
+	return []byte(s), nil
+}